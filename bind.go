@@ -0,0 +1,129 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Bind fills the fields of the struct pointed to by target from their
+// jsonstruct tags, e.g.
+//
+//	Port    int           `jsonstruct:".server.port" default:"8080" required:"true"`
+//	Timeout time.Duration `jsonstruct:".server.timeout" default:"30s"`
+//
+// Values are converted as by Get. A missing value leaves the field untouched
+// unless it has a default (parsed as JSON, or used as a plain string when it
+// isn't valid JSON) or is required. Pointer fields stay nil when their value
+// is missing so they can be used for optional settings.
+//
+// The tag paths of a struct field whose type has tagged fields of its own are
+// relative to that field's path (or to the parent's when it has none). Every
+// missing or invalid field is reported in the returned error.
+func (s JSONStruct) Bind(target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Bind target must be a non-nil pointer to a struct, not %T", target)
+	}
+
+	var errs []error
+	s.bindStruct("", v.Elem(), &errs)
+	return errors.Join(errs...)
+}
+
+func (s JSONStruct) bindStruct(prefix string, v reflect.Value, errs *[]error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		dotPath, tagged := field.Tag.Lookup("jsonstruct")
+		if dotPath == "-" {
+			continue
+		}
+		dotPath = prefix + dotPath
+
+		if nested, ok := structType(field.Type); ok && hasBindTags(nested) {
+			s.bindNested(dotPath, tagged, v.Field(i), errs)
+			continue
+		}
+
+		if tagged {
+			s.bindField(dotPath, field, v.Field(i), errs)
+		}
+	}
+}
+
+func (s JSONStruct) bindNested(dotPath string, tagged bool, v reflect.Value, errs *[]error) {
+	if v.Kind() != reflect.Ptr {
+		s.bindStruct(dotPath, v, errs)
+		return
+	}
+
+	// Optional sections are only allocated when they are present
+	if _, ok := s.FindElement(dotPath); tagged && !ok {
+		return
+	}
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	s.bindStruct(dotPath, v.Elem(), errs)
+}
+
+func (s JSONStruct) bindField(dotPath string, field reflect.StructField, v reflect.Value, errs *[]error) {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		defaultValue, hasDefault := field.Tag.Lookup("default")
+		required, _ := strconv.ParseBool(field.Tag.Get("required"))
+		switch {
+		case hasDefault:
+			value = parseDefault(defaultValue, field.Type)
+		case required:
+			*errs = append(*errs, &PathError{Path: dotPath, Err: ErrValueNotFound})
+			return
+		default:
+			return
+		}
+	}
+
+	err := convert(dotPath, value, v)
+	if err != nil {
+		*errs = append(*errs, err)
+	}
+}
+
+func parseDefault(defaultValue string, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.String {
+		return defaultValue
+	}
+
+	var value interface{}
+	err := json.Unmarshal([]byte(defaultValue), &value)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func structType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct && t != timeType
+}
+
+func hasBindTags(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("jsonstruct"); ok {
+			return true
+		}
+	}
+	return false
+}
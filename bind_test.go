@@ -0,0 +1,145 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type tlsConfig struct {
+	Cert string `jsonstruct:".cert" required:"true"`
+	Key  string `jsonstruct:".key" required:"true"`
+}
+
+type serverConfig struct {
+	Host    string        `jsonstruct:".host" default:"localhost"`
+	Port    int           `jsonstruct:".port" default:"8080" required:"true"`
+	Timeout time.Duration `jsonstruct:".timeout" default:"30s"`
+	TLS     *tlsConfig    `jsonstruct:".tls"`
+}
+
+type config struct {
+	Server   serverConfig  `jsonstruct:".server"`
+	Name     string        `jsonstruct:".name" required:"true"`
+	Children []string      `jsonstruct:".children"`
+	Phones   []phoneNumber `jsonstruct:".phoneNumbers"`
+	Spouse   *string       `jsonstruct:".spouse"`
+	Retries  *int          `jsonstruct:".retries"`
+	Tags     []string      `jsonstruct:".tags" default:"[\"a\", \"b\"]"`
+	Ignored  string        `jsonstruct:"-"`
+	Untagged string
+	internal string
+}
+
+var _ = Describe("Bind", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+	})
+
+	parse := func(data string) {
+		Expect(json.Unmarshal([]byte(data), &values)).To(Succeed())
+	}
+
+	It("fills fields from their paths", func() {
+		parse(`{
+			"name": "John",
+			"server": { "host": "example.com", "port": 9090, "timeout": "PT1M" },
+			"children": ["Catherine", "Thomas"],
+			"phoneNumbers": [{ "type": "home", "number": "212 555-1234" }],
+			"retries": 3
+		}`)
+
+		var c config
+		Expect(values.Bind(&c)).To(Succeed())
+
+		Expect(c.Name).To(Equal("John"))
+		Expect(c.Server.Host).To(Equal("example.com"))
+		Expect(c.Server.Port).To(Equal(9090))
+		Expect(c.Server.Timeout).To(Equal(time.Minute))
+		Expect(c.Children).To(Equal([]string{"Catherine", "Thomas"}))
+		Expect(c.Phones).To(Equal([]phoneNumber{{NumberType: "home", Number: "212 555-1234"}}))
+		Expect(*c.Retries).To(Equal(3))
+	})
+
+	It("applies defaults to missing values", func() {
+		parse(`{ "name": "John" }`)
+
+		var c config
+		Expect(values.Bind(&c)).To(Succeed())
+
+		Expect(c.Server.Host).To(Equal("localhost"))
+		Expect(c.Server.Port).To(Equal(8080))
+		Expect(c.Server.Timeout).To(Equal(30 * time.Second))
+		Expect(c.Tags).To(Equal([]string{"a", "b"}))
+	})
+
+	It("leaves optional pointers nil", func() {
+		parse(`{ "name": "John", "spouse": null }`)
+
+		var c config
+		Expect(values.Bind(&c)).To(Succeed())
+
+		Expect(c.Spouse).To(BeNil())
+		Expect(c.Retries).To(BeNil())
+		Expect(c.Server.TLS).To(BeNil())
+	})
+
+	It("binds optional nested structs when present", func() {
+		parse(`{ "name": "John", "server": { "tls": { "cert": "c.pem", "key": "k.pem" } } }`)
+
+		var c config
+		Expect(values.Bind(&c)).To(Succeed())
+
+		Expect(c.Server.TLS).To(Equal(&tlsConfig{Cert: "c.pem", Key: "k.pem"}))
+	})
+
+	It("doesn't touch untagged and ignored fields", func() {
+		parse(`{ "name": "John", "Untagged": "x", "Ignored": "y" }`)
+
+		c := config{Untagged: "keep", Ignored: "keep"}
+		Expect(values.Bind(&c)).To(Succeed())
+
+		Expect(c.Untagged).To(Equal("keep"))
+		Expect(c.Ignored).To(Equal("keep"))
+	})
+
+	It("reports every missing and invalid field", func() {
+		parse(`{
+			"server": { "port": "not a port", "timeout": "soon", "tls": { "cert": "c.pem" } },
+			"children": [true]
+		}`)
+
+		var c config
+		err := values.Bind(&c)
+		Expect(err).To(HaveOccurred())
+
+		var paths []string
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var pathErr *jsonstruct.PathError
+			Expect(errors.As(e, &pathErr)).To(BeTrue())
+			paths = append(paths, pathErr.Path)
+		}
+		Expect(paths).To(Equal([]string{
+			".server.port",
+			".server.timeout",
+			".server.tls.key",
+			".name",
+			".children[0]",
+		}))
+	})
+
+	It("rejects targets that aren't struct pointers", func() {
+		var c config
+		Expect(values.Bind(c)).To(HaveOccurred())
+		Expect(values.Bind((*config)(nil))).To(HaveOccurred())
+	})
+})
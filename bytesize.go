@@ -0,0 +1,162 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type byteUnit struct {
+	symbol string
+	size   int64
+}
+
+// Ordered from largest to smallest within each system
+var (
+	iecUnits = []byteUnit{
+		{"EiB", 1 << 60}, {"PiB", 1 << 50}, {"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	}
+	siUnits = []byteUnit{
+		{"EB", 1e18}, {"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3},
+	}
+)
+
+func (s JSONStruct) ByteSize(dotPath string) (int64, error) {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return 0, ErrValueNotFound
+	}
+
+	var size int64
+	var err error
+	switch value := value.(type) {
+	case string:
+		size, err = ParseByteSize(value)
+	case json.Number:
+		size, err = ParseByteSize(string(value))
+	case int, float64:
+		f, _ := toFloat64(value)
+		if f < 0 || f != math.Trunc(f) || f >= math.MaxInt64 {
+			err = fmt.Errorf("Invalid byte size %s", formatValue(value))
+		}
+		size = int64(f)
+	default:
+		return 0, conversionError(dotPath, value, reflect.TypeOf(int64(0)))
+	}
+	if err != nil {
+		return 0, &PathError{Path: dotPath, Err: err}
+	}
+
+	return size, nil
+}
+
+func (s JSONStruct) ByteSizeWithDefault(dotPath string, defaultValue int64) (int64, error) {
+	value, err := s.ByteSize(dotPath)
+	switch {
+	case err == ErrValueNotFound:
+		return defaultValue, nil
+	case err != nil:
+		return 0, err
+	default:
+		return value, nil
+	}
+}
+
+func (s JSONStruct) SetByteSize(dotPath string, value int64, opts ...SetOption) error {
+	if value < 0 {
+		return &PathError{Path: dotPath, Err: fmt.Errorf("Invalid byte size %d", value)}
+	}
+
+	parent, lastKey, err := s.findParent(dotPath, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = FormatByteSize(value)
+	return nil
+}
+
+// ParseByteSize parses a plain count of bytes or a number followed by an SI
+// (kB, MB, GB, ... in powers of 1000) or IEC (KiB, MiB, GiB, ... in powers of
+// 1024) unit. Units are case-insensitive so "KB" is 1000 bytes. The number may
+// have a fraction as long as the size is a whole number of bytes, e.g.
+// "1.5KiB" but not "1.1KiB".
+func ParseByteSize(value string) (int64, error) {
+	invalid := fmt.Errorf("Invalid byte size %q", value)
+
+	trimmed := strings.TrimSpace(value)
+	end := strings.IndexFunc(trimmed, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if end < 0 {
+		end = len(trimmed)
+	}
+	number, symbol := trimmed[:end], strings.TrimSpace(trimmed[end:])
+	if !isDecimal(number) {
+		return 0, invalid
+	}
+
+	multiplier, ok := byteUnitSize(symbol)
+	if !ok {
+		return 0, invalid
+	}
+
+	size, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, invalid
+	}
+	size.Mul(size, new(big.Rat).SetInt64(multiplier))
+
+	if !size.IsInt() {
+		return 0, fmt.Errorf("Byte size %q is not a whole number of bytes", value)
+	}
+	bytes := size.Num()
+	if !bytes.IsInt64() {
+		return 0, fmt.Errorf("Byte size %q overflows", value)
+	}
+
+	return bytes.Int64(), nil
+}
+
+func byteUnitSize(symbol string) (int64, bool) {
+	if symbol == "" || strings.EqualFold(symbol, "B") {
+		return 1, true
+	}
+
+	for _, units := range [][]byteUnit{iecUnits, siUnits} {
+		for _, unit := range units {
+			if strings.EqualFold(symbol, unit.symbol) {
+				return unit.size, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// FormatByteSize uses whichever unit expresses value as the smallest whole
+// number, e.g. "512MiB" or "1500kB", falling back to bytes.
+func FormatByteSize(value int64) string {
+	best := strconv.FormatInt(value, 10) + "B"
+	bestCount := value
+	for _, units := range [][]byteUnit{iecUnits, siUnits} {
+		for _, unit := range units {
+			if value%unit.size == 0 && abs(value/unit.size) < abs(bestCount) {
+				bestCount = value / unit.size
+				best = strconv.FormatInt(bestCount, 10) + unit.symbol
+			}
+		}
+	}
+	return best
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
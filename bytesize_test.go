@@ -0,0 +1,118 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Byte sizes", func() {
+	DescribeTable("ParseByteSize() with valid sizes", func(value string, expected int64) {
+		size, err := jsonstruct.ParseByteSize(value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(expected))
+	},
+		Entry("plain bytes", "1024", int64(1024)),
+		Entry("bytes unit", "10B", int64(10)),
+		Entry("SI", "512MB", int64(512000000)),
+		Entry("SI kilo", "2kB", int64(2000)),
+		Entry("IEC", "512MiB", int64(512*1024*1024)),
+		Entry("fractional IEC", "1.5GiB", int64(1610612736)),
+		Entry("case-insensitive", "1gib", int64(1<<30)),
+		Entry("spaces", " 3 KiB ", int64(3072)),
+		Entry("largest", "7EiB", int64(7<<60)),
+	)
+
+	DescribeTable("ParseByteSize() with invalid sizes", func(value string) {
+		_, err := jsonstruct.ParseByteSize(value)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("empty", ""),
+		Entry("unit only", "MB"),
+		Entry("unknown unit", "12XB"),
+		Entry("negative", "-1MB"),
+		Entry("two decimal points", "1.2.3MB"),
+		Entry("fractional bytes", "1.5"),
+		Entry("fractional bytes with a unit", "1.1KiB"),
+		Entry("overflow", "8EiB"),
+		Entry("overflow of plain bytes", "9223372036854775808"),
+	)
+
+	DescribeTable("FormatByteSize()", func(value int64, expected string) {
+		Expect(jsonstruct.FormatByteSize(value)).To(Equal(expected))
+	},
+		Entry("zero", int64(0), "0B"),
+		Entry("bytes", int64(1537), "1537B"),
+		Entry("IEC", int64(512*1024*1024), "512MiB"),
+		Entry("SI", int64(512000000), "512MB"),
+		Entry("smallest whole number", int64(1024000), "1000KiB"),
+		Entry("SI kilo", int64(1500000), "1500kB"),
+	)
+
+	Describe("ByteSize()", func() {
+		var (
+			values jsonstruct.JSONStruct
+		)
+
+		BeforeEach(func() {
+			values = jsonstruct.New()
+			Expect(json.Unmarshal([]byte(`{
+				"cache": "512MB",
+				"upload": "1.5GiB",
+				"plain": 4096,
+				"fractional": 1.5,
+				"fractional-string": "1.5",
+				"bad": "12XB",
+				"bool": true
+			}`), &values)).To(Succeed())
+		})
+
+		It("parses strings and plain numbers", func() {
+			Expect(values.ByteSize(".cache")).To(Equal(int64(512000000)))
+			Expect(values.ByteSize(".upload")).To(Equal(int64(1610612736)))
+			Expect(values.ByteSize(".plain")).To(Equal(int64(4096)))
+		})
+
+		It("returns a not found error when the value doesn't exist", func() {
+			_, err := values.ByteSize(".missing")
+			Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		})
+
+		It("returns errors for invalid values", func() {
+			_, err := values.ByteSize(".fractional")
+			Expect(err).To(MatchError(".fractional: Invalid byte size 1.5"))
+
+			_, err = values.ByteSize(".fractional-string")
+			Expect(err).To(MatchError(`.fractional-string: Byte size "1.5" is not a whole number of bytes`))
+
+			_, err = values.ByteSize(".bad")
+			Expect(err).To(MatchError(`.bad: Invalid byte size "12XB"`))
+
+			_, err = values.ByteSize(".bool")
+			Expect(err).To(MatchError(".bool: Cannot convert boolean to int64"))
+		})
+
+		It("returns the default value when a value isn't found", func() {
+			Expect(values.ByteSizeWithDefault(".missing", 10)).To(Equal(int64(10)))
+			Expect(values.ByteSizeWithDefault(".cache", 10)).To(Equal(int64(512000000)))
+
+			_, err := values.ByteSizeWithDefault(".bool", 10)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("sets sizes in the most natural unit", func() {
+			Expect(values.SetByteSize(".limits.upload", 2<<30)).To(Succeed())
+			Expect(values.StringWithDefault(".limits.upload", "")).To(Equal("2GiB"))
+			Expect(values.ByteSize(".limits.upload")).To(Equal(int64(2 << 30)))
+		})
+
+		It("refuses to set negative sizes", func() {
+			Expect(values.SetByteSize(".limits.upload", -5)).To(MatchError(".limits.upload: Invalid byte size -5"))
+			Expect(values.FindElement(".limits")).To(BeNil())
+		})
+	})
+})
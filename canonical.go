@@ -0,0 +1,158 @@
+package jsonstruct
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MarshalCanonical serializes the document as described by RFC 8785 (JSON
+// Canonicalization Scheme) so equal documents always produce identical
+// bytes. Numbers are serialized as IEEE 754 doubles, so integers beyond 2^53
+// lose precision.
+func (s JSONStruct) MarshalCanonical() ([]byte, error) {
+	var buf bytes.Buffer
+	err := writeCanonical(&buf, ".", map[string]interface{}(s))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, path string, value interface{}) error {
+	switch value := asObject(value).(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case string:
+		return writeCanonicalString(buf, path, value)
+	case float64, int, int64, json.Number:
+		f, ok := toFloat64(value)
+		if !ok {
+			return &PathError{Path: path, Err: fmt.Errorf("Invalid number %v", value)}
+		}
+		return writeCanonicalNumber(buf, path, f)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			err := writeCanonicalString(buf, path, key)
+			if err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			err = writeCanonical(buf, childPath(path, key), value[key])
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, element := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			err := writeCanonical(buf, path+"["+strconv.Itoa(i)+"]", element)
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		normalized, err := normalize(path, reflect.ValueOf(value))
+		if err != nil {
+			return err
+		}
+		return writeCanonical(buf, path, normalized)
+	}
+
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, path, value string) error {
+	if !utf8.ValidString(value) {
+		return &PathError{Path: path, Err: fmt.Errorf("Invalid UTF-8 in string %q", value)}
+	}
+
+	buf.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return nil
+}
+
+// writeCanonicalNumber follows the ECMAScript Number.prototype.toString
+// algorithm: shortest round-trip digits, plain notation from 1e-6 up to
+// 1e21 and exponent notation without padding outside that range
+func writeCanonicalNumber(buf *bytes.Buffer, path string, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return &PathError{Path: path, Err: fmt.Errorf("Number %v is not valid JSON", value)}
+	}
+	if value == 0 {
+		buf.WriteByte('0')
+		return nil
+	}
+
+	abs := math.Abs(value)
+	if abs >= 1e-6 && abs < 1e21 {
+		buf.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+		return nil
+	}
+
+	formatted := strconv.FormatFloat(value, 'e', -1, 64)
+	mantissa, exponent, _ := strings.Cut(formatted, "e")
+	sign, digits := exponent[:1], strings.TrimLeft(exponent[1:], "0")
+	buf.WriteString(mantissa + "e" + sign + digits)
+	return nil
+}
+
+func lessUTF16(a, b string) bool {
+	x, y := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return len(x) < len(y)
+}
@@ -0,0 +1,106 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MarshalCanonical", func() {
+	canonical := func(value interface{}) string {
+		data, err := jsonstruct.JSONStruct{"value": value}.MarshalCanonical()
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("sorts keys and removes whitespace", func() {
+		var values jsonstruct.JSONStruct
+		err := json.Unmarshal([]byte(`{
+			"b": [1, {"z": true, "a": null}],
+			"a": {"d": "x", "c": 2}
+		}`), &values)
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := values.MarshalCanonical()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"a":{"c":2,"d":"x"},"b":[1,{"a":null,"z":true}]}`))
+	})
+
+	It("sorts keys by UTF-16 code units", func() {
+		values := jsonstruct.JSONStruct{
+			"\u20ac":     "Euro Sign",
+			"\r":         "Carriage Return",
+			"\ufb33":     "Hebrew Letter Dalet With Dagesh",
+			"1":          "One",
+			"\U0001f600": "Emoji: Grinning Face",
+			"\u0080":     "Control",
+			"\u00f6":     "Latin Small Letter O With Diaeresis",
+		}
+
+		data, err := values.MarshalCanonical()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\"," +
+			"\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\"," +
+			"\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}"))
+	})
+
+	It("produces the same bytes for ints and parsed floats", func() {
+		set := jsonstruct.New()
+		Expect(set.SetInt(".a.b", 42)).To(Succeed())
+		Expect(set.SetList(".list", []interface{}{1, int64(2), json.Number("3.0")})).To(Succeed())
+
+		var parsed jsonstruct.JSONStruct
+		err := json.Unmarshal([]byte(`{"list":[1.0,2,3],"a":{"b":42.0}}`), &parsed)
+		Expect(err).NotTo(HaveOccurred())
+
+		setData, err := set.MarshalCanonical()
+		Expect(err).NotTo(HaveOccurred())
+		parsedData, err := parsed.MarshalCanonical()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(setData).To(Equal(parsedData))
+		Expect(string(setData)).To(Equal(`{"a":{"b":42},"list":[1,2,3]}`))
+	})
+
+	DescribeTable("formats numbers like ECMAScript",
+		func(value interface{}, expected string) {
+			Expect(canonical(value)).To(Equal(`{"value":` + expected + `}`))
+		},
+		Entry("zero", 0.0, "0"),
+		Entry("negative zero", math.Copysign(0, -1), "0"),
+		Entry("integer", 4.0, "4"),
+		Entry("fraction", 4.5, "4.5"),
+		Entry("small fraction", 0.002, "0.002"),
+		Entry("shortest round trip", 333333333.3333333, "333333333.3333333"),
+		Entry("largest safe integer", 9007199254740991.0, "9007199254740991"),
+		Entry("just below exponent notation", 1e20, "100000000000000000000"),
+		Entry("exponent notation", 1e21, "1e+21"),
+		Entry("large exponent", 1e+23, "1e+23"),
+		Entry("small exponent", 1e-7, "1e-7"),
+		Entry("smallest denormal", 5e-324, "5e-324"),
+		Entry("largest double", math.MaxFloat64, "1.7976931348623157e+308"),
+		Entry("negative", -1.5e-7, "-1.5e-7"),
+	)
+
+	It("escapes only what JSON requires", func() {
+		Expect(canonical("\"\\\b\f\n\r\t\u0001\u001f/<>& é")).
+			To(Equal(`{"value":"\"\\\b\f\n\r\t\u0001\u001f/<>&` + " é" + `"}`))
+	})
+
+	It("normalizes other Go values", func() {
+		Expect(canonical(map[string]int{"b": 2, "a": 1})).To(Equal(`{"value":{"a":1,"b":2}}`))
+		Expect(canonical(jsonstruct.JSONStruct{"a": []string{"x"}})).To(Equal(`{"value":{"a":["x"]}}`))
+	})
+
+	It("rejects values that cannot be represented", func() {
+		_, err := jsonstruct.JSONStruct{"a": []interface{}{math.NaN()}}.MarshalCanonical()
+		Expect(err).To(MatchError(".a[0]: Number NaN is not valid JSON"))
+
+		_, err = jsonstruct.JSONStruct{"a": "\xff"}.MarshalCanonical()
+		Expect(err).To(MatchError(`.a: Invalid UTF-8 in string "\xff"`))
+	})
+})
@@ -0,0 +1,341 @@
+// Command jsonstruct reads and edits values in JSON files using dot paths.
+//
+//	jsonstruct get <file> <dotpath> [--type string|int|duration|json]
+//	jsonstruct set <file> <dotpath> <value> [--type string|int|duration|json] [--force]
+//	jsonstruct delete <file> <dotpath>
+//
+// A file of "-" reads the document from stdin; set and delete then write the
+// modified document to stdout instead of editing the file in place. Without
+// --type, get prints strings as is and any other value as JSON, and set
+// stores the value as a string. Edited files are rewritten with sorted keys.
+//
+// Exit codes: 0 success, 1 read, write or parse failure, 2 usage error,
+// 3 path not found, 4 value has the wrong type.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+)
+
+const (
+	exitOK = iota
+	exitFailure
+	exitUsage
+	exitNotFound
+	exitWrongType
+)
+
+const usage = `Usage:
+  jsonstruct get <file> <dotpath> [--type string|int|duration|json]
+  jsonstruct set <file> <dotpath> <value> [--type string|int|duration|json] [--force]
+  jsonstruct delete <file> <dotpath>
+`
+
+var (
+	errUsage     = errors.New("Invalid usage")
+	errWrongType = errors.New("Wrong type")
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type command struct {
+	name    string
+	args    []string
+	valType string
+	force   bool
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cmd, err := parseArgs(args)
+	if err == nil {
+		err = cmd.execute(stdin, stdout)
+	}
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "%s\n%s", err, usage)
+		return exitUsage
+	case errors.Is(err, jsonstruct.ErrValueNotFound):
+		fmt.Fprintln(stderr, err)
+		return exitNotFound
+	case errors.Is(err, errWrongType), errors.Is(err, jsonstruct.ErrNotAnObject):
+		fmt.Fprintln(stderr, err)
+		return exitWrongType
+	default:
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+}
+
+// parseArgs allows flags anywhere after the command name
+func parseArgs(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{}, fmt.Errorf("%w: missing command", errUsage)
+	}
+
+	cmd := command{name: args[0]}
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--force" || arg == "-force":
+			cmd.force = true
+		case arg == "--type" || arg == "-type":
+			if i+1 == len(args) {
+				return command{}, fmt.Errorf("%w: missing --type value", errUsage)
+			}
+			i++
+			cmd.valType = args[i]
+		case strings.HasPrefix(arg, "--type=") || strings.HasPrefix(arg, "-type="):
+			cmd.valType = arg[strings.Index(arg, "=")+1:]
+		case arg == "--":
+			cmd.args = append(cmd.args, args[i+1:]...)
+			i = len(args)
+		default:
+			cmd.args = append(cmd.args, arg)
+		}
+	}
+
+	expected := map[string]int{"get": 2, "set": 3, "delete": 2}
+	count, ok := expected[cmd.name]
+	if !ok {
+		return command{}, fmt.Errorf("%w: unknown command %q", errUsage, cmd.name)
+	}
+	if len(cmd.args) != count {
+		return command{}, fmt.Errorf("%w: %s takes %d arguments", errUsage, cmd.name, count)
+	}
+
+	switch cmd.valType {
+	case "", "string", "int", "duration", "json":
+	default:
+		return command{}, fmt.Errorf("%w: unknown type %q", errUsage, cmd.valType)
+	}
+	if !strings.HasPrefix(cmd.args[1], ".") || cmd.name != "get" && cmd.args[1] == "." {
+		return command{}, fmt.Errorf("%w: invalid path %q", errUsage, cmd.args[1])
+	}
+	if cmd.name == "delete" && cmd.valType != "" {
+		return command{}, fmt.Errorf("%w: delete does not take --type", errUsage)
+	}
+	if cmd.name != "set" && cmd.force {
+		return command{}, fmt.Errorf("%w: only set takes --force", errUsage)
+	}
+
+	return cmd, nil
+}
+
+func (c command) execute(stdin io.Reader, stdout io.Writer) error {
+	file, dotPath := c.args[0], c.args[1]
+
+	s, err := read(file, stdin)
+	if err != nil {
+		return err
+	}
+
+	switch c.name {
+	case "get":
+		return c.get(s, dotPath, stdout)
+	case "set":
+		err = c.set(s, dotPath, c.args[2])
+	default:
+		if !s.Delete(dotPath) {
+			err = &jsonstruct.PathError{Path: dotPath, Err: jsonstruct.ErrValueNotFound}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return write(file, s, stdout)
+}
+
+func (c command) get(s jsonstruct.JSONStruct, dotPath string, stdout io.Writer) error {
+	var value interface{}
+	var err error
+	switch c.valType {
+	case "int":
+		value, err = jsonstruct.Get[int](s, dotPath)
+	case "string":
+		value, err = jsonstruct.Get[string](s, dotPath)
+	case "duration":
+		var d time.Duration
+		d, err = s.Duration(dotPath)
+		value = d.String()
+	default:
+		if dotPath == "." {
+			value = s
+			break
+		}
+		var ok bool
+		value, ok = s.FindElement(dotPath)
+		if !ok {
+			err = jsonstruct.ErrValueNotFound
+		}
+	}
+	if err != nil {
+		return getError(dotPath, err)
+	}
+
+	str, isString := value.(string)
+	if !isString || c.valType == "json" {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		str = string(data)
+	}
+
+	_, err = fmt.Fprintln(stdout, str)
+	return err
+}
+
+// getError reports every failure other than a missing value as a type error
+func getError(dotPath string, err error) error {
+	if err == jsonstruct.ErrValueNotFound {
+		return &jsonstruct.PathError{Path: dotPath, Err: err}
+	}
+
+	var pathErr *jsonstruct.PathError
+	if errors.As(err, &pathErr) {
+		dotPath, err = pathErr.Path, pathErr.Err
+	}
+	return &jsonstruct.PathError{Path: dotPath, Err: fmt.Errorf("%w: %s", errWrongType, err)}
+}
+
+func (c command) set(s jsonstruct.JSONStruct, dotPath, value string) error {
+	var opts []jsonstruct.SetOption
+	if c.force {
+		opts = append(opts, jsonstruct.Force)
+	}
+
+	switch c.valType {
+	case "int":
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not an int", errWrongType, value)
+		}
+		return s.SetInt(dotPath, i, opts...)
+	case "duration":
+		d, err := jsonstruct.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errWrongType, err)
+		}
+		return s.SetDuration(dotPath, d, opts...)
+	case "json":
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.UseNumber()
+		var decoded interface{}
+		err := decoder.Decode(&decoded)
+		if err == nil && decoder.More() {
+			err = errors.New("unexpected data after JSON value")
+		}
+		if err != nil {
+			return fmt.Errorf("%w: invalid JSON: %s", errWrongType, err)
+		}
+		// SetValue keeps json.Number values that Set would round to float64
+		return s.SetValue(dotPath, decoded, opts...)
+	default:
+		return s.SetString(dotPath, value, opts...)
+	}
+}
+
+func read(file string, stdin io.Reader) (jsonstruct.JSONStruct, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers exactly as written when the file is rewritten
+	decoder.UseNumber()
+	var s jsonstruct.JSONStruct
+	err = decoder.Decode(&s)
+	if err == nil && decoder.More() {
+		// Rewriting the file would otherwise drop everything after the
+		// first value
+		err = errors.New("unexpected data after JSON value")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if s == nil {
+		// null decodes without error
+		return nil, fmt.Errorf("%s: Document is not an object", file)
+	}
+	return s, nil
+}
+
+func write(file string, s jsonstruct.JSONStruct, stdout io.Writer) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if file == "-" {
+		_, err = stdout.Write(data)
+		return err
+	}
+
+	return writeAtomic(file, data)
+}
+
+// writeAtomic replaces file by renaming a temporary file written next to it so
+// readers never see a partially written document
+func writeAtomic(file string, data []byte) (err error) {
+	// Replace the target of a symbolic link rather than the link itself
+	file, err = filepath.EvalSymlinks(file)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
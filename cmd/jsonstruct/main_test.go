@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("jsonstruct", func() {
+	var (
+		dir    string
+		file   string
+		stdin  string
+		stdout *bytes.Buffer
+		stderr *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "jsonstruct")
+		Expect(err).NotTo(HaveOccurred())
+
+		file = filepath.Join(dir, "config.json")
+		Expect(os.WriteFile(file, []byte(`{
+			"server": {"host": "localhost", "port": 8080, "timeout": "30s"},
+			"big": 12345678901234567890,
+			"tags": ["a"]
+		}`), 0640)).To(Succeed())
+
+		stdin = ""
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	jsonstruct := func(args ...string) int {
+		return run(args, strings.NewReader(stdin), stdout, stderr)
+	}
+
+	readFile := func() string {
+		data, err := os.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	Describe("get", func() {
+		It("prints strings as is and other values as JSON", func() {
+			Expect(jsonstruct("get", file, ".server.host")).To(Equal(exitOK))
+			Expect(jsonstruct("get", file, ".server.port")).To(Equal(exitOK))
+			Expect(jsonstruct("get", file, ".tags")).To(Equal(exitOK))
+			Expect(jsonstruct("get", file, ".big")).To(Equal(exitOK))
+
+			Expect(stdout.String()).To(Equal("localhost\n8080\n[\"a\"]\n12345678901234567890\n"))
+		})
+
+		It("converts to the requested type", func() {
+			Expect(jsonstruct("get", file, ".server.port", "--type", "int")).To(Equal(exitOK))
+			Expect(jsonstruct("get", "--type=string", file, ".server.port")).To(Equal(exitOK))
+			Expect(jsonstruct("get", file, ".server.timeout", "--type", "duration")).To(Equal(exitOK))
+			Expect(jsonstruct("get", file, ".server.host", "--type", "json")).To(Equal(exitOK))
+
+			Expect(stdout.String()).To(Equal("8080\n8080\n30s\n\"localhost\"\n"))
+		})
+
+		It("prints the whole document", func() {
+			Expect(jsonstruct("get", file, ".")).To(Equal(exitOK))
+
+			Expect(stdout.String()).To(MatchJSON(readFile()))
+		})
+
+		It("reads stdin", func() {
+			stdin = `{"a": {"b": "c"}}`
+
+			Expect(jsonstruct("get", "-", ".a.b")).To(Equal(exitOK))
+
+			Expect(stdout.String()).To(Equal("c\n"))
+		})
+
+		It("distinguishes missing values from type errors", func() {
+			Expect(jsonstruct("get", file, ".server.missing")).To(Equal(exitNotFound))
+			Expect(stderr.String()).To(Equal(".server.missing: Value not found\n"))
+
+			stderr.Reset()
+			Expect(jsonstruct("get", file, ".server.host", "--type", "int")).To(Equal(exitWrongType))
+			Expect(stderr.String()).To(Equal(".server.host: Wrong type: Cannot convert string to int\n"))
+
+			stderr.Reset()
+			Expect(jsonstruct("get", file, ".server.host", "--type", "duration")).To(Equal(exitWrongType))
+			Expect(stderr.String()).To(HavePrefix(".server.host: Wrong type: "))
+		})
+	})
+
+	Describe("set", func() {
+		It("edits the file in place", func() {
+			Expect(jsonstruct("set", file, ".server.host", "example.com")).To(Equal(exitOK))
+			Expect(jsonstruct("set", file, ".server.port", "9090", "--type", "int")).To(Equal(exitOK))
+			Expect(jsonstruct("set", file, ".server.timeout", "1m", "--type", "duration")).To(Equal(exitOK))
+			Expect(jsonstruct("set", file, ".server.tls", `{"enabled": true}`, "--type", "json")).To(Equal(exitOK))
+
+			Expect(readFile()).To(MatchJSON(`{
+				"server": {"host": "example.com", "port": 9090, "timeout": "1m0s", "tls": {"enabled": true}},
+				"big": 12345678901234567890,
+				"tags": ["a"]
+			}`))
+			Expect(readFile()).To(ContainSubstring("12345678901234567890"))
+			Expect(stdout.String()).To(BeEmpty())
+
+			info, err := os.Stat(file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0640)))
+
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+
+		It("keeps JSON numbers exactly as written", func() {
+			Expect(jsonstruct("set", file, ".server.ids", `{"big": 12345678901234567891}`, "--type", "json")).
+				To(Equal(exitOK))
+			Expect(jsonstruct("set", file, ".count", "12345678901234567893", "--type", "json")).To(Equal(exitOK))
+
+			Expect(readFile()).To(ContainSubstring(`"big": 12345678901234567891`))
+			Expect(readFile()).To(ContainSubstring(`"count": 12345678901234567893`))
+		})
+
+		It("edits the target of a symbolic link", func() {
+			link := filepath.Join(dir, "link.json")
+			Expect(os.Symlink(file, link)).To(Succeed())
+
+			Expect(jsonstruct("set", link, ".server.host", "example.com")).To(Equal(exitOK))
+
+			info, err := os.Lstat(link)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode() & os.ModeSymlink).NotTo(BeZero())
+			Expect(readFile()).To(ContainSubstring(`"host": "example.com"`))
+		})
+
+		It("writes stdin edits to stdout", func() {
+			stdin = `{"a": 1}`
+
+			Expect(jsonstruct("set", "-", ".b", "2", "--type", "int")).To(Equal(exitOK))
+
+			Expect(stdout.String()).To(MatchJSON(`{"a": 1, "b": 2}`))
+		})
+
+		It("refuses to replace values that are not objects unless forced", func() {
+			Expect(jsonstruct("set", file, ".server.host.name", "x")).To(Equal(exitWrongType))
+			Expect(stderr.String()).To(Equal(".server.host: Value is not an object\n"))
+
+			Expect(jsonstruct("set", file, ".server.host.name", "x", "--force")).To(Equal(exitOK))
+			Expect(readFile()).To(ContainSubstring(`"name": "x"`))
+		})
+
+		It("rejects values of the wrong type without changing the file", func() {
+			before := readFile()
+
+			Expect(jsonstruct("set", file, ".server.port", "many", "--type", "int")).To(Equal(exitWrongType))
+			Expect(jsonstruct("set", file, ".server.port", "{", "--type", "json")).To(Equal(exitWrongType))
+			Expect(jsonstruct("set", file, ".server.port", "soon", "--type", "duration")).To(Equal(exitWrongType))
+
+			Expect(readFile()).To(Equal(before))
+		})
+	})
+
+	Describe("delete", func() {
+		It("removes values", func() {
+			Expect(jsonstruct("delete", file, ".server.timeout")).To(Equal(exitOK))
+
+			Expect(readFile()).NotTo(ContainSubstring("timeout"))
+		})
+
+		It("reports missing values", func() {
+			Expect(jsonstruct("delete", file, ".server.missing")).To(Equal(exitNotFound))
+			Expect(stderr.String()).To(Equal(".server.missing: Value not found\n"))
+		})
+	})
+
+	It("reports usage errors", func() {
+		Expect(jsonstruct()).To(Equal(exitUsage))
+		Expect(jsonstruct("list", file)).To(Equal(exitUsage))
+		Expect(jsonstruct("get", file)).To(Equal(exitUsage))
+		Expect(jsonstruct("get", file, "server")).To(Equal(exitUsage))
+		Expect(jsonstruct("get", file, ".a", "--type", "float")).To(Equal(exitUsage))
+		Expect(jsonstruct("get", file, ".a", "--type")).To(Equal(exitUsage))
+		Expect(jsonstruct("delete", file, ".")).To(Equal(exitUsage))
+		Expect(jsonstruct("delete", file, ".a", "--force")).To(Equal(exitUsage))
+		Expect(stderr.String()).To(ContainSubstring("Usage:"))
+	})
+
+	It("reports read and parse failures", func() {
+		Expect(jsonstruct("get", filepath.Join(dir, "missing.json"), ".a")).To(Equal(exitFailure))
+
+		stdin = "{"
+		Expect(jsonstruct("get", "-", ".a")).To(Equal(exitFailure))
+		Expect(stderr.String()).To(HaveSuffix("-: unexpected EOF\n"))
+
+		Expect(os.WriteFile(file, []byte(`{"a":1}`+"\n"+`{"b":2}`), 0640)).To(Succeed())
+		stderr.Reset()
+		Expect(jsonstruct("set", file, ".c", "x")).To(Equal(exitFailure))
+		Expect(stderr.String()).To(Equal(file + ": unexpected data after JSON value\n"))
+		Expect(readFile()).To(Equal(`{"a":1}` + "\n" + `{"b":2}`))
+
+		stderr.Reset()
+		stdin = "null"
+		Expect(jsonstruct("set", "-", ".a", "b")).To(Equal(exitFailure))
+		Expect(stderr.String()).To(Equal("-: Document is not an object\n"))
+	})
+})
@@ -0,0 +1,147 @@
+package jsonstruct
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type ConstraintError struct {
+	Path       string
+	Value      interface{}
+	Constraint string
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%s: Value %s is not %s", e.Path, formatValue(e.Value), e.Constraint)
+}
+
+func (s JSONStruct) StringEnum(dotPath string, allowed ...string) (string, error) {
+	value, ok := s.String(dotPath)
+	if !ok {
+		return "", s.constraintLookupError(dotPath, reflect.TypeOf(""))
+	}
+
+	for _, a := range allowed {
+		if value == a {
+			return value, nil
+		}
+	}
+
+	quoted := make([]string, len(allowed))
+	for i, a := range allowed {
+		quoted[i] = formatValue(a)
+	}
+	return "", &ConstraintError{
+		Path:       dotPath,
+		Value:      value,
+		Constraint: "one of " + strings.Join(quoted, ", "),
+	}
+}
+
+func (s JSONStruct) IntInRange(dotPath string, min, max int) (int, error) {
+	raw, ok := s.FindElement(dotPath)
+	if !ok {
+		return 0, ErrValueNotFound
+	}
+
+	// Int would truncate a fraction into the range
+	if number, ok := toFloat64(raw); ok && number != math.Trunc(number) {
+		return 0, &ConstraintError{Path: dotPath, Value: raw, Constraint: "an integer"}
+	}
+	var value int
+	err := convert(dotPath, raw, reflect.ValueOf(&value).Elem())
+	if err != nil {
+		return 0, err
+	}
+
+	if value < min || value > max {
+		return 0, &ConstraintError{
+			Path:       dotPath,
+			Value:      value,
+			Constraint: fmt.Sprintf("in the range [%d, %d]", min, max),
+		}
+	}
+
+	return value, nil
+}
+
+func (s JSONStruct) DurationInRange(dotPath string, min, max time.Duration) (time.Duration, error) {
+	value, err := s.Duration(dotPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if value < min || value > max {
+		return 0, &ConstraintError{
+			Path:       dotPath,
+			Value:      value.String(),
+			Constraint: fmt.Sprintf("in the range [%s, %s]", min, max),
+		}
+	}
+
+	return value, nil
+}
+
+func (s JSONStruct) constraintLookupError(dotPath string, t reflect.Type) error {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return ErrValueNotFound
+	}
+
+	return conversionError(dotPath, value, t)
+}
+
+// Constraints collects the errors from many constrained getters so that all
+// violations can be reported at once. Each getter returns the zero value when
+// its constraint fails.
+type Constraints struct {
+	s    JSONStruct
+	errs []error
+}
+
+func (s JSONStruct) Constraints() *Constraints {
+	return &Constraints{s: s}
+}
+
+func (c *Constraints) StringEnum(dotPath string, allowed ...string) string {
+	value, err := c.s.StringEnum(dotPath, allowed...)
+	c.add(dotPath, err)
+	return value
+}
+
+func (c *Constraints) IntInRange(dotPath string, min, max int) int {
+	value, err := c.s.IntInRange(dotPath, min, max)
+	c.add(dotPath, err)
+	return value
+}
+
+func (c *Constraints) DurationInRange(dotPath string, min, max time.Duration) time.Duration {
+	value, err := c.s.DurationInRange(dotPath, min, max)
+	c.add(dotPath, err)
+	return value
+}
+
+// Err returns every violation recorded so far joined into a single error, or
+// nil if there were none.
+func (c *Constraints) Err() error {
+	return errors.Join(c.errs...)
+}
+
+func (c *Constraints) add(dotPath string, err error) {
+	if err == nil {
+		return
+	}
+
+	// Name the path of every error since the joined errors are reported
+	// together
+	var pathErr *PathError
+	var constraintErr *ConstraintError
+	if !errors.As(err, &pathErr) && !errors.As(err, &constraintErr) {
+		err = &PathError{Path: dotPath, Err: err}
+	}
+	c.errs = append(c.errs, err)
+}
@@ -0,0 +1,133 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Constraints", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(json.Unmarshal([]byte(`{
+			"log": { "level": "info", "bad-level": "trace" },
+			"pool": { "size": 16, "too-big": 1000, "name": "x" },
+			"timeout": "5s",
+			"long-timeout": "1h"
+		}`), &values)).To(Succeed())
+	})
+
+	Describe("StringEnum()", func() {
+		It("returns allowed values", func() {
+			Expect(values.StringEnum(".log.level", "debug", "info")).To(Equal("info"))
+		})
+
+		It("returns a constraint error for other values", func() {
+			_, err := values.StringEnum(".log.bad-level", "debug", "info")
+			Expect(err).To(MatchError(`.log.bad-level: Value "trace" is not one of "debug", "info"`))
+
+			var constraintErr *jsonstruct.ConstraintError
+			Expect(errors.As(err, &constraintErr)).To(BeTrue())
+			Expect(constraintErr.Path).To(Equal(".log.bad-level"))
+			Expect(constraintErr.Value).To(Equal("trace"))
+		})
+
+		It("returns a not found error when the value doesn't exist", func() {
+			_, err := values.StringEnum(".missing", "a")
+			Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		})
+
+		It("returns a path error for values of the wrong type", func() {
+			_, err := values.StringEnum(".log", "a")
+			Expect(err).To(MatchError(".log: Cannot convert object to string"))
+		})
+	})
+
+	Describe("IntInRange()", func() {
+		It("returns values within the range", func() {
+			Expect(values.IntInRange(".pool.size", 1, 256)).To(Equal(16))
+			Expect(values.IntInRange(".pool.size", 16, 16)).To(Equal(16))
+		})
+
+		It("returns a constraint error for values outside the range", func() {
+			_, err := values.IntInRange(".pool.too-big", 1, 256)
+			Expect(err).To(MatchError(".pool.too-big: Value 1000 is not in the range [1, 256]"))
+		})
+
+		It("returns a constraint error for fractional values", func() {
+			Expect(values.SetValue(".pool.fraction", 256.5)).To(Succeed())
+
+			_, err := values.IntInRange(".pool.fraction", 1, 256)
+			Expect(err).To(MatchError(".pool.fraction: Value 256.5 is not an integer"))
+
+			var constraintErr *jsonstruct.ConstraintError
+			Expect(errors.As(err, &constraintErr)).To(BeTrue())
+		})
+
+		It("returns lookup errors", func() {
+			_, err := values.IntInRange(".missing", 1, 2)
+			Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+			_, err = values.IntInRange(".pool.name", 1, 2)
+			Expect(err).To(MatchError(".pool.name: Cannot convert string to int"))
+		})
+	})
+
+	Describe("DurationInRange()", func() {
+		It("returns values within the range", func() {
+			Expect(values.DurationInRange(".timeout", time.Second, time.Minute)).To(Equal(5 * time.Second))
+		})
+
+		It("returns a constraint error for values outside the range", func() {
+			_, err := values.DurationInRange(".long-timeout", time.Second, time.Minute)
+			Expect(err).To(MatchError(`.long-timeout: Value "1h0m0s" is not in the range [1s, 1m0s]`))
+		})
+	})
+
+	Describe("collecting violations", func() {
+		It("reports every violation at once", func() {
+			c := values.Constraints()
+			level := c.StringEnum(".log.level", "debug", "info")
+			c.StringEnum(".log.bad-level", "debug", "info")
+			size := c.IntInRange(".pool.size", 1, 256)
+			c.IntInRange(".pool.too-big", 1, 256)
+			c.DurationInRange(".missing", time.Second, time.Minute)
+
+			Expect(level).To(Equal("info"))
+			Expect(size).To(Equal(16))
+
+			err := c.Err()
+			Expect(err).To(MatchError(`.log.bad-level: Value "trace" is not one of "debug", "info"
+.pool.too-big: Value 1000 is not in the range [1, 256]
+.missing: Value not found`))
+			Expect(errors.Is(err, jsonstruct.ErrValueNotFound)).To(BeTrue())
+		})
+
+		It("names the path of values that cannot be parsed", func() {
+			Expect(values.SetString(".bogus-timeout", "bogus")).To(Succeed())
+
+			c := values.Constraints()
+			c.DurationInRange(".bogus-timeout", time.Second, time.Minute)
+
+			err := c.Err()
+			Expect(err).To(MatchError(HavePrefix(".bogus-timeout: ")))
+			var pathErr *jsonstruct.PathError
+			Expect(errors.As(err, &pathErr)).To(BeTrue())
+			Expect(pathErr.Path).To(Equal(".bogus-timeout"))
+		})
+
+		It("returns nil when there are no violations", func() {
+			c := values.Constraints()
+			c.IntInRange(".pool.size", 1, 256)
+			Expect(c.Err()).To(BeNil())
+		})
+	})
+})
@@ -7,12 +7,24 @@ func (s JSONStruct) DeepCopy() JSONStruct {
 func deepCopy(msi map[string]interface{}) map[string]interface{} {
 	copy := make(map[string]interface{})
 	for key, value := range msi {
-		switch t := value.(type) {
-		case map[string]interface{}:
-			copy[key] = deepCopy(t)
-		default:
-			copy[key] = value
-		}
+		copy[key] = deepCopyValue(value)
 	}
 	return copy
 }
+
+func deepCopyValue(value interface{}) interface{} {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		return deepCopy(t)
+	case JSONStruct:
+		return deepCopy(t)
+	case []interface{}:
+		list := make([]interface{}, len(t))
+		for i, element := range t {
+			list[i] = deepCopyValue(element)
+		}
+		return list
+	default:
+		return value
+	}
+}
@@ -30,4 +30,20 @@ var _ = Describe("Copy", func() {
 		copySubSub := copySub["sub"]
 		Expect(reflect.ValueOf(origSubSub)).NotTo(Equal(reflect.ValueOf(copySubSub)))
 	})
+
+	It("does not share lists or the values within them", func() {
+		orig := jsonstruct.New()
+		sub := jsonstruct.New()
+		sub.SetString(".x", "y")
+		orig.SetList(".list", []interface{}{1, sub})
+
+		copy := orig.DeepCopy()
+
+		copyList := copy["list"].([]interface{})
+		copyList[0] = 2
+		copyList[1].(map[string]interface{})["x"] = "z"
+
+		Expect(orig["list"]).To(Equal([]interface{}{1, sub}))
+		Expect(sub["x"]).To(Equal("y"))
+	})
 })
@@ -0,0 +1,199 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationWithUnit is like Duration but interprets numeric values as a count
+// of unit rather than of seconds.
+func (s JSONStruct) DurationWithUnit(dotPath string, unit time.Duration) (time.Duration, error) {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return 0, ErrValueNotFound
+	}
+
+	duration, err := toDuration(value, unit)
+	if err == errWrongType {
+		return 0, conversionError(dotPath, value, durationType)
+	}
+
+	return duration, err
+}
+
+func (s JSONStruct) DurationWithUnitAndDefault(dotPath string, unit, defaultValue time.Duration) (time.Duration, error) {
+	value, err := s.DurationWithUnit(dotPath, unit)
+	return durationWithDefault(value, err, defaultValue)
+}
+
+// toDuration accepts numbers (as a count of unit), Go duration strings
+// optionally starting with a day count (e.g. "7d" or "1d12h") and ISO 8601
+// durations (e.g. "PT1H30M").
+func toDuration(value interface{}, unit time.Duration) (time.Duration, error) {
+	switch value := value.(type) {
+	case string:
+		return ParseDuration(value)
+	case int:
+		return scaleDuration(float64(value), unit)
+	case float64:
+		return scaleDuration(value, unit)
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return 0, err
+		}
+		return scaleDuration(f, unit)
+	default:
+		return 0, errWrongType
+	}
+}
+
+func scaleDuration(value float64, unit time.Duration) (time.Duration, error) {
+	scaled := value * float64(unit)
+	if math.IsNaN(scaled) || scaled >= math.MaxInt64 || scaled < math.MinInt64 {
+		return 0, fmt.Errorf("Duration %s overflows", strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return time.Duration(math.Round(scaled)), nil
+}
+
+// ParseDuration parses Go duration strings, Go duration strings with a
+// leading day count (e.g. "7d" or "1d12h") and ISO 8601 durations (e.g.
+// "PT1H30M" or "P1W"). ISO 8601 years and months are rejected since their
+// length varies.
+func ParseDuration(value string) (time.Duration, error) {
+	unsigned := strings.TrimLeft(value, "+-")
+	if len(value)-len(unsigned) > 1 {
+		return 0, fmt.Errorf("time: invalid duration %q", value)
+	}
+	if strings.HasPrefix(unsigned, "P") {
+		return parseISO8601Duration(value)
+	}
+
+	dayIndex := strings.IndexByte(value, 'd')
+	if dayIndex < 0 {
+		return time.ParseDuration(value)
+	}
+
+	invalid := fmt.Errorf("time: invalid duration %q", value)
+
+	days := value[:dayIndex]
+	negative := strings.HasPrefix(days, "-")
+	days = strings.TrimLeft(days, "+-")
+	if !isDecimal(days) {
+		return 0, invalid
+	}
+	count, err := strconv.ParseFloat(days, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	duration, err := scaleDuration(count, 24*time.Hour)
+	if err != nil {
+		return 0, err
+	}
+
+	// Only the day count may carry the sign
+	if rest := value[dayIndex+1:]; rest != "" {
+		if strings.ContainsAny(rest[:1], "+-") {
+			return 0, invalid
+		}
+		remainder, err := time.ParseDuration(rest)
+		if err != nil || duration > math.MaxInt64-remainder {
+			return 0, invalid
+		}
+		duration += remainder
+	}
+
+	if negative {
+		duration = -duration
+	}
+	return duration, nil
+}
+
+func isDecimal(value string) bool {
+	digits, dots := 0, 0
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+func parseISO8601Duration(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("Invalid ISO 8601 duration %q", value)
+
+	rest := value
+	negative := false
+	if rest[0] == '-' || rest[0] == '+' {
+		negative = rest[0] == '-'
+		rest = rest[1:]
+	}
+	rest = rest[1:] // P
+
+	dateUnits := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	timeUnits := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	order := "YMWD"
+	units := dateUnits
+
+	var total float64
+	components := 0
+	inTime := false
+	for len(rest) > 0 {
+		if rest[0] == 'T' {
+			if inTime {
+				return 0, invalid
+			}
+			inTime, units, order, rest = true, timeUnits, "HMS", rest[1:]
+			if rest == "" {
+				return 0, invalid
+			}
+			continue
+		}
+
+		end := strings.IndexAny(rest, "YMWDHS")
+		if end < 0 {
+			return 0, invalid
+		}
+		// ISO 8601 allows a comma as the decimal separator
+		digits := strings.Replace(rest[:end], ",", ".", 1)
+		if !isDecimal(digits) {
+			return 0, invalid
+		}
+		number, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			return 0, invalid
+		}
+
+		designator := rest[end]
+		position := strings.IndexByte(order, designator)
+		if position < 0 {
+			return 0, invalid
+		}
+		order = order[position+1:]
+
+		unit, ok := units[designator]
+		if !ok && number != 0 {
+			return 0, fmt.Errorf("%s: years and months have no fixed length", invalid)
+		}
+		total += number * float64(unit)
+		components++
+		rest = rest[end+1:]
+	}
+
+	if components == 0 {
+		return 0, invalid
+	}
+	if negative {
+		total = -total
+	}
+	return scaleDuration(total, 1)
+}
@@ -0,0 +1,121 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Durations", func() {
+	DescribeTable("ParseDuration() with valid durations", func(value string, expected time.Duration) {
+		duration, err := jsonstruct.ParseDuration(value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(duration).To(Equal(expected))
+	},
+		Entry("Go duration", "1h30m", 90*time.Minute),
+		Entry("negative Go duration", "-20s", -20*time.Second),
+		Entry("days", "7d", 7*24*time.Hour),
+		Entry("fractional days", "1.5d", 36*time.Hour),
+		Entry("days and hours", "1d12h", 36*time.Hour),
+		Entry("negative days", "-1d6h", -30*time.Hour),
+		Entry("ISO 8601 time", "PT1H30M", 90*time.Minute),
+		Entry("ISO 8601 seconds", "PT0.5S", 500*time.Millisecond),
+		Entry("ISO 8601 comma separator", "PT1,5S", 1500*time.Millisecond),
+		Entry("ISO 8601 days and time", "P1DT2H", 26*time.Hour),
+		Entry("ISO 8601 weeks", "P2W", 14*24*time.Hour),
+		Entry("ISO 8601 zero years", "P0Y1D", 24*time.Hour),
+		Entry("negative ISO 8601", "-PT10M", -10*time.Minute),
+	)
+
+	DescribeTable("ParseDuration() with invalid durations", func(value string) {
+		_, err := jsonstruct.ParseDuration(value)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("empty", ""),
+		Entry("no units", "10"),
+		Entry("unknown unit", "3x"),
+		Entry("days without a count", "d"),
+		Entry("days not first", "12h1d"),
+		Entry("signed remainder", "1d-2h"),
+		Entry("double sign", "+-1d"),
+		Entry("ISO 8601 without components", "P"),
+		Entry("ISO 8601 empty time", "P1DT"),
+		Entry("ISO 8601 out of order", "PT1S1H"),
+		Entry("ISO 8601 time unit in date", "P1H"),
+		Entry("ISO 8601 years", "P1Y"),
+		Entry("ISO 8601 months", "P1M"),
+		Entry("ISO 8601 missing designator", "PT10"),
+	)
+
+	Describe("Duration()", func() {
+		var (
+			values jsonstruct.JSONStruct
+		)
+
+		BeforeEach(func() {
+			values = jsonstruct.New()
+			Expect(json.Unmarshal([]byte(`{
+				"seconds": 30,
+				"fractional": 1.5,
+				"iso": "PT1M",
+				"days": "7d",
+				"huge": 1e300,
+				"bool": true
+			}`), &values)).To(Succeed())
+		})
+
+		It("interprets numbers as seconds", func() {
+			Expect(values.Duration(".seconds")).To(Equal(30 * time.Second))
+			Expect(values.Duration(".fractional")).To(Equal(1500 * time.Millisecond))
+
+			Expect(values.SetInt(".set", 2)).To(Succeed())
+			Expect(values.Duration(".set")).To(Equal(2 * time.Second))
+		})
+
+		It("accepts ISO 8601 durations and days", func() {
+			Expect(values.Duration(".iso")).To(Equal(time.Minute))
+			Expect(values.Duration(".days")).To(Equal(7 * 24 * time.Hour))
+		})
+
+		It("returns an error for durations that overflow", func() {
+			_, err := values.Duration(".huge")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns a path error for values of the wrong type", func() {
+			_, err := values.Duration(".bool")
+			Expect(err).To(MatchError(".bool: Cannot convert boolean to time.Duration"))
+		})
+
+		It("uses the same rules for compiled paths, lists and Get", func() {
+			Expect(values.DurationAt(jsonstruct.MustCompilePath(".seconds"))).To(Equal(30 * time.Second))
+			Expect(jsonstruct.Get[time.Duration](values, ".iso")).To(Equal(time.Minute))
+
+			Expect(values.SetList(".list", []interface{}{1, "PT2S", "1d"})).To(Succeed())
+			Expect(values.DurationList(".list")).To(Equal([]time.Duration{time.Second, 2 * time.Second, 24 * time.Hour}))
+		})
+	})
+
+	Describe("DurationWithUnit()", func() {
+		It("interprets numbers in the given unit", func() {
+			values := jsonstruct.New()
+			Expect(values.SetInt(".timeout", 250)).To(Succeed())
+
+			Expect(values.DurationWithUnit(".timeout", time.Millisecond)).To(Equal(250 * time.Millisecond))
+			Expect(values.DurationWithUnitAndDefault(".timeout", time.Millisecond, time.Hour)).To(Equal(250 * time.Millisecond))
+			Expect(values.DurationWithUnitAndDefault(".missing", time.Millisecond, time.Hour)).To(Equal(time.Hour))
+		})
+
+		It("still parses strings", func() {
+			values := jsonstruct.New()
+			Expect(values.SetString(".timeout", "2s")).To(Succeed())
+
+			Expect(values.DurationWithUnit(".timeout", time.Millisecond)).To(Equal(2 * time.Second))
+		})
+	})
+})
@@ -0,0 +1,374 @@
+package jsonstruct
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType   = reflect.TypeOf(time.Duration(0))
+	timeType       = reflect.TypeOf(time.Time{})
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// Get returns the value at dotPath converted to T. Numbers convert between
+// all numeric types as long as no precision is lost, durations and times are
+// parsed from strings, slices and maps are converted element-wise and any
+// other type (typically a struct) is decoded as if by encoding/json.
+func Get[T any](s JSONStruct, dotPath string) (T, error) {
+	var result T
+
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return result, ErrValueNotFound
+	}
+
+	err := convert(dotPath, value, reflect.ValueOf(&result).Elem())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return result, nil
+}
+
+// Set stores value at dotPath in the same representation the other setters
+// and the JSON parser use, so it can be read back with any getter.
+func Set[T any](s JSONStruct, dotPath string, value T, opts ...SetOption) error {
+	normalized, err := normalize(dotPath, reflect.ValueOf(&value).Elem())
+	if err != nil {
+		return err
+	}
+
+	parent, lastKey, err := s.findParent(dotPath, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = normalized
+	return nil
+}
+
+func convert(path string, value interface{}, target reflect.Value) error {
+	t := target.Type()
+
+	switch t {
+	case durationType:
+		duration, err := toDuration(value, time.Second)
+		if err == errWrongType {
+			return conversionError(path, value, t)
+		}
+		if err != nil {
+			return &PathError{Path: path, Err: err}
+		}
+		target.SetInt(int64(duration))
+		return nil
+	case timeType:
+		str, ok := value.(string)
+		if !ok {
+			return conversionError(path, value, t)
+		}
+		parsed, err := parseTime(str)
+		if err != nil {
+			return &PathError{Path: path, Err: err}
+		}
+		target.Set(reflect.ValueOf(parsed))
+		return nil
+	case jsonNumberType:
+		number, ok := toJSONNumber(value)
+		if !ok {
+			return conversionError(path, value, t)
+		}
+		target.SetString(string(number))
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if value == nil {
+			return nil
+		}
+		if !reflect.TypeOf(value).AssignableTo(t) {
+			return conversionError(path, value, t)
+		}
+		target.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return conversionError(path, value, t)
+		}
+		target.SetBool(b)
+		return nil
+	case reflect.String:
+		str, ok := toString(value)
+		if !ok {
+			if number, isNumber := value.(json.Number); isNumber {
+				str, ok = string(number), true
+			}
+		}
+		if !ok {
+			return conversionError(path, value, t)
+		}
+		target.SetString(str)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if number, ok := value.(json.Number); ok {
+			// Parsed exactly since float64 can't hold every int64
+			if i, err := strconv.ParseInt(string(number), 10, 64); err == nil {
+				if target.OverflowInt(i) {
+					return conversionError(path, value, t)
+				}
+				target.SetInt(i)
+				return nil
+			}
+		}
+		number, ok := toFloat64(value)
+		if !ok || number != math.Trunc(number) || number < math.MinInt64 || number >= math.MaxInt64 ||
+			!exactInteger(value, number) ||
+			target.OverflowInt(int64(number)) {
+			return conversionError(path, value, t)
+		}
+		target.SetInt(int64(number))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if number, ok := value.(json.Number); ok {
+			if u, err := strconv.ParseUint(string(number), 10, 64); err == nil {
+				if target.OverflowUint(u) {
+					return conversionError(path, value, t)
+				}
+				target.SetUint(u)
+				return nil
+			}
+		}
+		number, ok := toFloat64(value)
+		if !ok || number != math.Trunc(number) || number < 0 || number >= math.MaxUint64 ||
+			!exactInteger(value, number) ||
+			target.OverflowUint(uint64(number)) {
+			return conversionError(path, value, t)
+		}
+		target.SetUint(uint64(number))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		number, ok := toFloat64(value)
+		if !ok || target.OverflowFloat(number) {
+			return conversionError(path, value, t)
+		}
+		target.SetFloat(number)
+		return nil
+	case reflect.Slice:
+		list, ok := value.([]interface{})
+		if !ok {
+			return conversionError(path, value, t)
+		}
+		slice := reflect.MakeSlice(t, len(list), len(list))
+		for i, element := range list {
+			err := convert(path+"["+strconv.Itoa(i)+"]", element, slice.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+		return nil
+	case reflect.Map:
+		object, ok := asObject(value).(map[string]interface{})
+		if !ok || t.Key().Kind() != reflect.String {
+			return conversionError(path, value, t)
+		}
+		m := reflect.MakeMapWithSize(t, len(object))
+		for key, child := range object {
+			element := reflect.New(t.Elem()).Elem()
+			err := convert(childPath(path, key), child, element)
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), element)
+		}
+		target.Set(m)
+		return nil
+	case reflect.Ptr:
+		if value == nil {
+			target.Set(reflect.Zero(t))
+			return nil
+		}
+		elem := reflect.New(t.Elem())
+		err := convert(path, value, elem.Elem())
+		if err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return &PathError{Path: path, Err: err}
+		}
+		err = json.Unmarshal(data, target.Addr().Interface())
+		if err != nil {
+			return &PathError{Path: path, Err: err}
+		}
+		return nil
+	}
+}
+
+// exactInteger reports whether a json.Number that isn't written as a plain
+// integer (e.g. "1e3" or "2.0") was converted to number without rounding.
+// Anything from 2^53 up might have been rounded to get there.
+func exactInteger(value interface{}, number float64) bool {
+	if _, ok := value.(json.Number); !ok {
+		return true
+	}
+	return math.Abs(number) < 1<<53
+}
+
+func normalize(path string, value reflect.Value) (interface{}, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	switch value.Type() {
+	case durationType:
+		return time.Duration(value.Int()).String(), nil
+	case timeType:
+		return value.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	case jsonNumberType:
+		number := json.Number(value.String())
+		if i, err := strconv.Atoi(string(number)); err == nil {
+			return i, nil
+		}
+		f, err := number.Float64()
+		if err != nil {
+			return nil, &PathError{Path: path, Err: err}
+		}
+		return f, nil
+	}
+
+	// Types with their own JSON form, and []byte which encodes as a base64
+	// string, must be serialized like encoding/json does
+	if value.Type().Implements(jsonMarshalerType) || value.Type().Implements(textMarshalerType) ||
+		value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 && !value.IsNil() {
+		return normalizeJSON(path, value)
+	}
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return normalize(path, value.Elem())
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return normalize(path, value.Elem())
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := value.Int()
+		if i < math.MinInt || i > math.MaxInt {
+			return float64(i), nil
+		}
+		return int(i), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := value.Uint()
+		if u > math.MaxInt {
+			return float64(u), nil
+		}
+		return int(u), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+		list := make([]interface{}, value.Len())
+		for i := range list {
+			element, err := normalize(path+"["+strconv.Itoa(i)+"]", value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = element
+		}
+		return list, nil
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if value.IsNil() {
+			return nil, nil
+		}
+		msi := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			element, err := normalize(childPath(path, key), iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			msi[key] = element
+		}
+		return msi, nil
+	}
+
+	return normalizeJSON(path, value)
+}
+
+func normalizeJSON(path string, value reflect.Value) (interface{}, error) {
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return nil, &PathError{Path: path, Err: err}
+	}
+	var decoded interface{}
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, &PathError{Path: path, Err: err}
+	}
+	return decoded, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var parsed time.Time
+		parsed, err = time.Parse(layout, value)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func toJSONNumber(value interface{}) (json.Number, bool) {
+	switch value := value.(type) {
+	case json.Number:
+		return value, true
+	case int:
+		return json.Number(strconv.Itoa(value)), true
+	case int64:
+		return json.Number(strconv.FormatInt(value, 10)), true
+	case float64:
+		return json.Number(strconv.FormatFloat(value, 'f', -1, 64)), true
+	default:
+		return "", false
+	}
+}
+
+func conversionError(path string, value interface{}, t reflect.Type) error {
+	return &PathError{Path: path, Err: fmt.Errorf("Cannot convert %s to %s", typeName(value), t)}
+}
@@ -0,0 +1,219 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type phoneNumber struct {
+	NumberType string `json:"type"`
+	Number     string `json:"number"`
+}
+
+var _ = Describe("Generics", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(json.Unmarshal([]byte(`{
+			"firstName": "John",
+			"isAlive": true,
+			"age": 25,
+			"height": 1.8,
+			"timeout": "20s",
+			"born": "1979-05-27T07:32:00-08:00",
+			"birthday": "1979-05-27",
+			"children": ["Catherine", "Thomas"],
+			"scores": [1, 2, 3],
+			"phoneNumbers": [
+				{ "type": "home", "number": "212 555-1234" }
+			],
+			"address": { "city": "New York", "state": "NY" },
+			"spouse": null
+		}`), &values)).To(Succeed())
+	})
+
+	Describe("Get()", func() {
+		It("returns a not found error for missing values", func() {
+			_, err := jsonstruct.Get[string](values, ".missing")
+			Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		})
+
+		It("returns strings", func() {
+			Expect(jsonstruct.Get[string](values, ".firstName")).To(Equal("John"))
+			Expect(jsonstruct.Get[string](values, ".height")).To(Equal("1.8"))
+		})
+
+		It("returns bools", func() {
+			Expect(jsonstruct.Get[bool](values, ".isAlive")).To(BeTrue())
+		})
+
+		It("converts numbers between types", func() {
+			Expect(jsonstruct.Get[int](values, ".age")).To(Equal(25))
+			Expect(jsonstruct.Get[int64](values, ".age")).To(Equal(int64(25)))
+			Expect(jsonstruct.Get[uint8](values, ".age")).To(Equal(uint8(25)))
+			Expect(jsonstruct.Get[float64](values, ".height")).To(Equal(1.8))
+			Expect(jsonstruct.Get[json.Number](values, ".age")).To(Equal(json.Number("25")))
+
+			Expect(values.SetInt(".set", 7)).To(Succeed())
+			Expect(jsonstruct.Get[float64](values, ".set")).To(Equal(7.0))
+
+			values["number"] = json.Number("12")
+			Expect(jsonstruct.Get[int](values, ".number")).To(Equal(12))
+			values["exponent"] = json.Number("1e3")
+			Expect(jsonstruct.Get[int](values, ".exponent")).To(Equal(1000))
+		})
+
+		It("converts large json.Numbers exactly", func() {
+			values["big"] = json.Number("9007199254740993")
+			Expect(jsonstruct.Get[int64](values, ".big")).To(Equal(int64(9007199254740993)))
+			Expect(jsonstruct.Get[uint64](values, ".big")).To(Equal(uint64(9007199254740993)))
+
+			values["huge"] = json.Number("18446744073709551615")
+			Expect(jsonstruct.Get[uint64](values, ".huge")).To(Equal(uint64(18446744073709551615)))
+			_, err := jsonstruct.Get[int64](values, ".huge")
+			Expect(err).To(MatchError(".huge: Cannot convert number to int64"))
+
+			values["inexact"] = json.Number("9007199254740993.0")
+			_, err = jsonstruct.Get[int64](values, ".inexact")
+			Expect(err).To(MatchError(".inexact: Cannot convert number to int64"))
+		})
+
+		It("rejects numbers that would lose precision", func() {
+			_, err := jsonstruct.Get[int](values, ".height")
+			Expect(err).To(HaveOccurred())
+
+			values["big"] = 300
+			_, err = jsonstruct.Get[uint8](values, ".big")
+			Expect(err).To(HaveOccurred())
+
+			values["negative"] = -1
+			_, err = jsonstruct.Get[uint](values, ".negative")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("parses durations", func() {
+			Expect(jsonstruct.Get[time.Duration](values, ".timeout")).To(Equal(20 * time.Second))
+
+			_, err := jsonstruct.Get[time.Duration](values, ".firstName")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("parses times", func() {
+			born, err := jsonstruct.Get[time.Time](values, ".born")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(born.Unix()).To(Equal(int64(296667120)))
+
+			birthday, err := jsonstruct.Get[time.Time](values, ".birthday")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(birthday).To(Equal(time.Date(1979, 5, 27, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("converts slices element-wise", func() {
+			Expect(jsonstruct.Get[[]string](values, ".children")).To(Equal([]string{"Catherine", "Thomas"}))
+			Expect(jsonstruct.Get[[]int](values, ".scores")).To(Equal([]int{1, 2, 3}))
+			Expect(jsonstruct.Get[[]interface{}](values, ".children")).To(Equal([]interface{}{"Catherine", "Thomas"}))
+		})
+
+		It("reports the index of a bad element", func() {
+			values["mixed"] = []interface{}{"a", true}
+			_, err := jsonstruct.Get[[]string](values, ".mixed")
+
+			var pathErr *jsonstruct.PathError
+			Expect(errors.As(err, &pathErr)).To(BeTrue())
+			Expect(pathErr.Path).To(Equal(".mixed[1]"))
+		})
+
+		It("converts maps", func() {
+			Expect(jsonstruct.Get[map[string]string](values, ".address")).To(Equal(map[string]string{
+				"city":  "New York",
+				"state": "NY",
+			}))
+		})
+
+		It("decodes structs", func() {
+			numbers, err := jsonstruct.Get[[]phoneNumber](values, ".phoneNumbers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numbers).To(Equal([]phoneNumber{{NumberType: "home", Number: "212 555-1234"}}))
+		})
+
+		It("returns nil pointers for null values", func() {
+			spouse, err := jsonstruct.Get[*string](values, ".spouse")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spouse).To(BeNil())
+
+			name, err := jsonstruct.Get[*string](values, ".firstName")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*name).To(Equal("John"))
+		})
+
+		It("returns a path error for mismatched types", func() {
+			_, err := jsonstruct.Get[bool](values, ".firstName")
+			Expect(err).To(MatchError(".firstName: Cannot convert string to bool"))
+		})
+	})
+
+	Describe("Set()", func() {
+		It("stores values with their own JSON form the way encoding/json does", func() {
+			values = jsonstruct.New()
+
+			Expect(jsonstruct.Set(values, ".bytes", []byte("abc"))).To(Succeed())
+			Expect(jsonstruct.Set(values, ".raw", json.RawMessage(`{"a":1}`))).To(Succeed())
+
+			Expect(values.StringWithDefault(".bytes", "")).To(Equal("YWJj"))
+			Expect(values.IntWithDefault(".raw.a", 0)).To(Equal(1))
+		})
+
+		It("stores values that the other getters can read", func() {
+			values = jsonstruct.New()
+
+			Expect(jsonstruct.Set(values, ".string", "value")).To(Succeed())
+			Expect(jsonstruct.Set(values, ".int64", int64(42))).To(Succeed())
+			Expect(jsonstruct.Set(values, ".uint", uint(43))).To(Succeed())
+			Expect(jsonstruct.Set(values, ".duration", 5*time.Second)).To(Succeed())
+			Expect(jsonstruct.Set(values, ".strings", []string{"a", "b"})).To(Succeed())
+
+			Expect(values.StringWithDefault(".string", "")).To(Equal("value"))
+			Expect(values.IntWithDefault(".int64", 0)).To(Equal(42))
+			Expect(values.IntWithDefault(".uint", 0)).To(Equal(43))
+			Expect(values.DurationWithDefault(".duration", 0)).To(Equal(5 * time.Second))
+			list, ok := values.List(".strings")
+			Expect(ok).To(BeTrue())
+			Expect(list).To(Equal([]interface{}{"a", "b"}))
+		})
+
+		It("round trips through Get", func() {
+			born := time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC)
+			Expect(jsonstruct.Set(values, ".new.born", born)).To(Succeed())
+			Expect(jsonstruct.Get[time.Time](values, ".new.born")).To(Equal(born))
+
+			numbers := []phoneNumber{{NumberType: "office", Number: "646 555-4567"}}
+			Expect(jsonstruct.Set(values, ".new.numbers", numbers)).To(Succeed())
+			Expect(jsonstruct.Get[[]phoneNumber](values, ".new.numbers")).To(Equal(numbers))
+
+			Expect(jsonstruct.Set(values, ".new.map", map[string]int{"a": 1})).To(Succeed())
+			Expect(jsonstruct.Get[map[string]int](values, ".new.map")).To(Equal(map[string]int{"a": 1}))
+		})
+
+		It("stores nil pointers as null", func() {
+			var spouse *string
+			Expect(jsonstruct.Set(values, ".spouse", spouse)).To(Succeed())
+
+			value, ok := values.FindElement(".spouse")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(BeNil())
+		})
+
+		It("returns an error for unsupported paths", func() {
+			Expect(jsonstruct.Set(values, "bad", 1)).To(Equal(jsonstruct.ErrUnsupportedPath))
+		})
+	})
+})
@@ -14,15 +14,33 @@ func New() JSONStruct {
 }
 
 var (
-	ErrValueNotFound = errors.New("Value not found")
+	ErrValueNotFound   = errors.New("Value not found")
+	ErrUnsupportedPath = errors.New("Only . paths are currently supported")
 )
 
+type PathError struct {
+	Path string
+	Err  error
+}
+
+func (e *PathError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
 func (s JSONStruct) String(dotPath string) (string, bool) {
 	value, ok := s.FindElement(dotPath)
 	if !ok {
 		return "", false
 	}
 
+	return toString(value)
+}
+
+func toString(value interface{}) (string, bool) {
 	switch value := value.(type) {
 	case string:
 		return value, true
@@ -50,6 +68,10 @@ func (s JSONStruct) Int(dotPath string) (int, bool) {
 		return 0, false
 	}
 
+	return toInt(value)
+}
+
+func toInt(value interface{}) (int, bool) {
 	switch value := value.(type) {
 	case float64:
 		// Parsed values are of value float64
@@ -71,22 +93,18 @@ func (s JSONStruct) IntWithDefault(dotPath string, defaultValue int) int {
 	return value
 }
 
+// Duration treats numeric values as seconds. See ParseDuration for the
+// supported string forms.
 func (s JSONStruct) Duration(dotPath string) (time.Duration, error) {
-	value, ok := s.String(dotPath)
-	if !ok {
-		return 0, ErrValueNotFound
-	}
-
-	duration, err := time.ParseDuration(value)
-	if err != nil {
-		return 0, err
-	}
-
-	return duration, nil
+	return s.DurationWithUnit(dotPath, time.Second)
 }
 
 func (s JSONStruct) DurationWithDefault(dotPath string, defaultValue time.Duration) (time.Duration, error) {
 	value, err := s.Duration(dotPath)
+	return durationWithDefault(value, err, defaultValue)
+}
+
+func durationWithDefault(value time.Duration, err error, defaultValue time.Duration) (time.Duration, error) {
 	switch {
 	case err == ErrValueNotFound:
 		return defaultValue, nil
@@ -108,12 +126,15 @@ func (s JSONStruct) List(dotPath string) ([]interface{}, bool) {
 }
 
 func (s JSONStruct) FindElement(dotPath string) (interface{}, bool) {
-	if dotPath[0:1] != "." {
+	keys, err := splitPath(dotPath)
+	if err != nil {
 		return nil, false
 	}
 
-	keys := strings.Split(dotPath[1:], ".")
+	return s.findElement(keys)
+}
 
+func (s JSONStruct) findElement(keys []string) (interface{}, bool) {
 	parent := s
 
 	var value interface{}
@@ -125,7 +146,7 @@ func (s JSONStruct) FindElement(dotPath string) (interface{}, bool) {
 		}
 
 		if i+1 < len(keys) {
-			parent, ok = value.(map[string]interface{})
+			parent, ok = asObject(value).(map[string]interface{})
 			if !ok {
 				return nil, false
 			}
@@ -134,3 +155,11 @@ func (s JSONStruct) FindElement(dotPath string) (interface{}, bool) {
 
 	return value, true
 }
+
+func splitPath(dotPath string) ([]string, error) {
+	if len(dotPath) == 0 || dotPath[0:1] != "." {
+		return nil, ErrUnsupportedPath
+	}
+
+	return strings.Split(dotPath[1:], "."), nil
+}
@@ -0,0 +1,21 @@
+module github.com/myshkin5/jsonstruct
+
+go 1.21
+
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/onsi/ginkgo v1.16.5
+	github.com/onsi/gomega v1.27.10
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require (
+	github.com/fsnotify/fsnotify v1.4.9 // indirect
+	github.com/google/go-cmp v0.5.9 // indirect
+	github.com/nxadm/tail v1.4.8 // indirect
+	golang.org/x/net v0.17.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,43 @@
+package jsonstruct
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+)
+
+// Hash writes the canonical serialization (see MarshalCanonical) of the value
+// at dotPath to h, so equal subtrees always produce equal digests. A dotPath of
+// "." hashes the whole document.
+func (s JSONStruct) Hash(dotPath string, h hash.Hash) error {
+	var value interface{} = map[string]interface{}(s)
+	if dotPath != "." {
+		var ok bool
+		value, ok = s.FindElement(dotPath)
+		if !ok {
+			return ErrValueNotFound
+		}
+	}
+
+	var buf bytes.Buffer
+	err := writeCanonical(&buf, dotPath, value)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.Write(buf.Bytes())
+	return err
+}
+
+// Fingerprint returns the hex encoded SHA-256 Hash of the value at dotPath,
+// or an empty string if the value is missing or cannot be serialized.
+func (s JSONStruct) Fingerprint(dotPath string) string {
+	h := sha256.New()
+	err := s.Hash(dotPath, h)
+	if err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,79 @@
+package jsonstruct_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hash", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		err := json.Unmarshal([]byte(`{
+			"database": {"host": "db", "port": 5432},
+			"server": {"port": 8080}
+		}`), &values)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("hashes the canonical serialization of a subtree", func() {
+		h := sha256.New()
+		Expect(values.Hash(".database", h)).To(Succeed())
+
+		expected := sha256.Sum256([]byte(`{"host":"db","port":5432}`))
+		Expect(h.Sum(nil)).To(Equal(expected[:]))
+	})
+
+	It("hashes the whole document", func() {
+		h := sha256.New()
+		Expect(values.Hash(".", h)).To(Succeed())
+
+		expected := sha256.Sum256([]byte(`{"database":{"host":"db","port":5432},"server":{"port":8080}}`))
+		Expect(h.Sum(nil)).To(Equal(expected[:]))
+	})
+
+	It("returns errors", func() {
+		Expect(values.Hash(".missing", sha256.New())).To(MatchError(jsonstruct.ErrValueNotFound))
+
+		Expect(values.SetList(".bad", []interface{}{math.Inf(1)})).To(Succeed())
+		Expect(values.Hash(".bad", sha256.New())).To(MatchError(".bad[0]: Number +Inf is not valid JSON"))
+	})
+
+	Describe("Fingerprint", func() {
+		It("returns the hex SHA-256 digest", func() {
+			expected := sha256.Sum256([]byte(`{"port":8080}`))
+			Expect(values.Fingerprint(".server")).To(Equal(hex.EncodeToString(expected[:])))
+		})
+
+		It("is stable across representations of the same values", func() {
+			rebuilt := jsonstruct.New()
+			Expect(rebuilt.SetInt(".database.port", 5432)).To(Succeed())
+			Expect(rebuilt.SetString(".database.host", "db")).To(Succeed())
+
+			Expect(rebuilt.Fingerprint(".database")).To(Equal(values.Fingerprint(".database")))
+		})
+
+		It("changes when the subtree changes", func() {
+			before := values.Fingerprint(".database")
+			serverBefore := values.Fingerprint(".server")
+
+			Expect(values.SetInt(".database.port", 5433)).To(Succeed())
+
+			Expect(values.Fingerprint(".database")).NotTo(Equal(before))
+			Expect(values.Fingerprint(".server")).To(Equal(serverBefore))
+		})
+
+		It("returns an empty string for missing values", func() {
+			Expect(values.Fingerprint(".missing")).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// ImmutableJSONStruct is a read-only document. With returns a modified
+// document that shares every subtree not on the modified path with the
+// original. Lists and objects returned by the getters are shared and must not
+// be modified.
+type ImmutableJSONStruct struct {
+	root map[string]interface{}
+}
+
+func NewImmutable(s JSONStruct) ImmutableJSONStruct {
+	return ImmutableJSONStruct{root: deepCopy(s)}
+}
+
+func (i ImmutableJSONStruct) JSONStruct() JSONStruct {
+	return JSONStruct(deepCopy(i.root))
+}
+
+func (i ImmutableJSONStruct) With(dotPath string, value interface{}, opts ...SetOption) (ImmutableJSONStruct, error) {
+	keys, err := splitPath(dotPath)
+	if err != nil {
+		return ImmutableJSONStruct{}, err
+	}
+
+	if duration, ok := value.(time.Duration); ok {
+		value = duration.String()
+	} else {
+		value = deepCopyValue(value)
+	}
+
+	root, err := with(i.root, keys, 0, value, hasOption(opts, Force))
+	if err != nil {
+		return ImmutableJSONStruct{}, err
+	}
+
+	return ImmutableJSONStruct{root: root}, nil
+}
+
+func with(parent map[string]interface{}, keys []string, depth int, value interface{}, force bool) (map[string]interface{}, error) {
+	key := keys[depth]
+
+	var replacement interface{} = value
+	if depth+1 < len(keys) {
+		child, ok := parent[key].(map[string]interface{})
+		if !ok && parent[key] != nil && !force {
+			return nil, &PathError{Path: "." + strings.Join(keys[:depth+1], "."), Err: ErrNotAnObject}
+		}
+
+		var err error
+		replacement, err = with(child, keys, depth+1, value, force)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	copy := make(map[string]interface{}, len(parent)+1)
+	for k, child := range parent {
+		copy[k] = child
+	}
+	copy[key] = replacement
+	return copy, nil
+}
+
+func (i ImmutableJSONStruct) MarshalJSON() ([]byte, error) {
+	if i.root == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(i.root)
+}
+
+func (i ImmutableJSONStruct) String(dotPath string) (string, bool) {
+	return JSONStruct(i.root).String(dotPath)
+}
+
+func (i ImmutableJSONStruct) StringWithDefault(dotPath, defaultValue string) string {
+	return JSONStruct(i.root).StringWithDefault(dotPath, defaultValue)
+}
+
+func (i ImmutableJSONStruct) Int(dotPath string) (int, bool) {
+	return JSONStruct(i.root).Int(dotPath)
+}
+
+func (i ImmutableJSONStruct) IntWithDefault(dotPath string, defaultValue int) int {
+	return JSONStruct(i.root).IntWithDefault(dotPath, defaultValue)
+}
+
+func (i ImmutableJSONStruct) Duration(dotPath string) (time.Duration, error) {
+	return JSONStruct(i.root).Duration(dotPath)
+}
+
+func (i ImmutableJSONStruct) DurationWithDefault(dotPath string, defaultValue time.Duration) (time.Duration, error) {
+	return JSONStruct(i.root).DurationWithDefault(dotPath, defaultValue)
+}
+
+func (i ImmutableJSONStruct) List(dotPath string) ([]interface{}, bool) {
+	return JSONStruct(i.root).List(dotPath)
+}
+
+func (i ImmutableJSONStruct) FindElement(dotPath string) (interface{}, bool) {
+	return JSONStruct(i.root).FindElement(dotPath)
+}
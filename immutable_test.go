@@ -0,0 +1,131 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImmutableJSONStruct", func() {
+	var (
+		orig jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		orig = jsonstruct.New()
+		Expect(orig.SetString(".server.host", "localhost")).To(Succeed())
+		Expect(orig.SetInt(".server.port", 8080)).To(Succeed())
+		Expect(orig.SetString(".database.name", "db")).To(Succeed())
+	})
+
+	It("is not affected by changes to the document it was created from", func() {
+		values := jsonstruct.NewImmutable(orig)
+		Expect(orig.SetString(".server.host", "changed")).To(Succeed())
+
+		Expect(values.StringWithDefault(".server.host", "")).To(Equal("localhost"))
+	})
+
+	It("exposes the typed getters", func() {
+		values := jsonstruct.NewImmutable(orig)
+
+		Expect(values.StringWithDefault(".server.host", "")).To(Equal("localhost"))
+		Expect(values.IntWithDefault(".server.port", 0)).To(Equal(8080))
+		_, ok := values.Int(".server.host")
+		Expect(ok).To(BeFalse())
+	})
+
+	Describe("With()", func() {
+		It("returns a new document without modifying the original", func() {
+			values := jsonstruct.NewImmutable(orig)
+
+			updated, err := values.With(".server.port", 9090)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(values.IntWithDefault(".server.port", 0)).To(Equal(8080))
+			Expect(updated.IntWithDefault(".server.port", 0)).To(Equal(9090))
+			Expect(updated.StringWithDefault(".server.host", "")).To(Equal("localhost"))
+		})
+
+		It("shares unchanged subtrees", func() {
+			values := jsonstruct.NewImmutable(orig)
+
+			updated, err := values.With(".server.port", 9090)
+			Expect(err).NotTo(HaveOccurred())
+
+			origDatabase, ok := values.FindElement(".database")
+			Expect(ok).To(BeTrue())
+			updatedDatabase, ok := updated.FindElement(".database")
+			Expect(ok).To(BeTrue())
+			Expect(reflect.ValueOf(updatedDatabase).Pointer()).To(Equal(reflect.ValueOf(origDatabase).Pointer()))
+
+			origServer, _ := values.FindElement(".server")
+			updatedServer, _ := updated.FindElement(".server")
+			Expect(reflect.ValueOf(updatedServer).Pointer()).NotTo(Equal(reflect.ValueOf(origServer).Pointer()))
+		})
+
+		It("creates intermediate objects", func() {
+			updated, err := jsonstruct.ImmutableJSONStruct{}.With(".one.two.three", "hi")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.StringWithDefault(".one.two.three", "")).To(Equal("hi"))
+		})
+
+		It("stores durations in the same form as SetDuration", func() {
+			updated, err := jsonstruct.NewImmutable(orig).With(".timeout", 5*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.DurationWithDefault(".timeout", 0)).To(Equal(5 * time.Second))
+		})
+
+		It("is not affected by later changes to the value", func() {
+			list := []interface{}{"a"}
+			updated, err := jsonstruct.NewImmutable(orig).With(".list", list)
+			Expect(err).NotTo(HaveOccurred())
+
+			list[0] = "b"
+			value, ok := updated.List(".list")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal([]interface{}{"a"}))
+		})
+
+		It("does not overwrite intermediate values unless forced", func() {
+			values := jsonstruct.NewImmutable(orig)
+
+			_, err := values.With(".server.port.number", 1)
+			Expect(err).To(MatchError(".server.port: Value is not an object"))
+
+			updated, err := values.With(".server.port.number", 1, jsonstruct.Force)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.IntWithDefault(".server.port.number", 0)).To(Equal(1))
+			Expect(values.IntWithDefault(".server.port", 0)).To(Equal(8080))
+		})
+
+		It("returns an error for unsupported paths", func() {
+			_, err := jsonstruct.NewImmutable(orig).With("server", 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("converts back to a mutable document", func() {
+		values := jsonstruct.NewImmutable(orig)
+
+		mutable := values.JSONStruct()
+		Expect(mutable).To(Equal(orig))
+		Expect(mutable.SetString(".server.host", "changed")).To(Succeed())
+
+		Expect(values.StringWithDefault(".server.host", "")).To(Equal("localhost"))
+	})
+
+	It("marshals to JSON", func() {
+		data, err := json.Marshal(jsonstruct.NewImmutable(orig))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"server": {"host": "localhost", "port": 8080}, "database": {"name": "db"}}`))
+
+		data, err = json.Marshal(jsonstruct.ImmutableJSONStruct{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{}`))
+	})
+})
@@ -0,0 +1,194 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const IncludeKey = "$include"
+
+var (
+	ErrIncludeCycle       = errors.New("Include cycle")
+	ErrIncludeOutsideRoot = errors.New("Include is outside of the root directory")
+)
+
+// LoadFile parses the named file from fsys and resolves its includes (see
+// ResolveIncludes). Files ending in .yaml, .yml or .toml are parsed as YAML
+// or TOML and any other file as JSON.
+func LoadFile(fsys fs.FS, name string) (JSONStruct, error) {
+	value, err := loadInclude(fsys, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotAnObject)
+	}
+	return JSONStruct(object), nil
+}
+
+// ResolveIncludes replaces every object containing an "$include" key with the
+// contents of the referenced files, merged in order, with the object's other
+// keys merged on top. Objects are merged key by key; any other value replaces
+// the included one. "$include" holds a file name or a list of file names
+// relative to the directory of name, the name of the document within fsys.
+// Files can only be read from fsys, so os.DirFS(dir) restricts includes to
+// dir (though it follows symbolic links).
+func (s JSONStruct) ResolveIncludes(fsys fs.FS, name string) error {
+	value, err := resolveIncludes(fsys, name, []string{name}, ".", map[string]interface{}(s))
+	if err != nil {
+		return err
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return &PathError{Path: ".", Err: ErrNotAnObject}
+	}
+	for key := range s {
+		delete(s, key)
+	}
+	for key, value := range object {
+		s[key] = value
+	}
+	return nil
+}
+
+func loadInclude(fsys fs.FS, name string, stack []string) (interface{}, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	switch path.Ext(name) {
+	case ".yaml", ".yml":
+		value, err = ParseYAML(data)
+	case ".toml":
+		value, err = ParseTOML(data)
+	default:
+		err = json.Unmarshal(data, &value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return resolveIncludes(fsys, name, append(stack, name), ".", value)
+}
+
+func resolveIncludes(fsys fs.FS, name string, stack []string, dotPath string, value interface{}) (interface{}, error) {
+	switch value := asObject(value).(type) {
+	case map[string]interface{}:
+		var result interface{}
+		if include, ok := value[IncludeKey]; ok {
+			var err error
+			result, err = includeFiles(fsys, name, stack, dotPath, include)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		siblings := make(map[string]interface{}, len(value))
+		for key, child := range value {
+			if key == IncludeKey {
+				continue
+			}
+			resolved, err := resolveIncludes(fsys, name, stack, childPath(dotPath, key), child)
+			if err != nil {
+				return nil, err
+			}
+			siblings[key] = resolved
+		}
+
+		if _, ok := value[IncludeKey]; !ok || len(siblings) > 0 {
+			result = mergeIncluded(result, siblings)
+		}
+		return result, nil
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, element := range value {
+			resolved, err := resolveIncludes(fsys, name, stack, dotPath+"["+strconv.Itoa(i)+"]", element)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = resolved
+		}
+		return list, nil
+	default:
+		return value, nil
+	}
+}
+
+func includeFiles(fsys fs.FS, name string, stack []string, dotPath string, include interface{}) (interface{}, error) {
+	var names []string
+	switch include := include.(type) {
+	case string:
+		names = []string{include}
+	case []interface{}:
+		for _, element := range include {
+			str, ok := element.(string)
+			if !ok {
+				return nil, &PathError{Path: dotPath, Err: fmt.Errorf("%s must be a file name or a list of file names", IncludeKey)}
+			}
+			names = append(names, str)
+		}
+	default:
+		return nil, &PathError{Path: dotPath, Err: fmt.Errorf("%s must be a file name or a list of file names", IncludeKey)}
+	}
+
+	var result interface{}
+	for i, includeName := range names {
+		if path.IsAbs(includeName) {
+			return nil, &PathError{Path: dotPath, Err: fmt.Errorf("%s: %w", includeName, ErrIncludeOutsideRoot)}
+		}
+		target := path.Join(path.Dir(name), includeName)
+		if !fs.ValidPath(target) {
+			return nil, &PathError{Path: dotPath, Err: fmt.Errorf("%s: %w", includeName, ErrIncludeOutsideRoot)}
+		}
+
+		for n, active := range stack {
+			if active == target {
+				cycle := append(stack[n:len(stack):len(stack)], target)
+				return nil, &PathError{
+					Path: dotPath,
+					Err:  fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(cycle, " -> ")),
+				}
+			}
+		}
+
+		included, err := loadInclude(fsys, target, stack)
+		if err != nil {
+			return nil, &PathError{Path: dotPath, Err: fmt.Errorf("Including %s: %w", target, err)}
+		}
+
+		if i == 0 {
+			result = included
+		} else {
+			result = mergeIncluded(result, included)
+		}
+	}
+	return result, nil
+}
+
+// mergeIncluded merges override into base, replacing anything that is not an
+// object in both
+func mergeIncluded(base, override interface{}) interface{} {
+	baseObject, ok := asObject(base).(map[string]interface{})
+	overrideObject, isObject := asObject(override).(map[string]interface{})
+	if !ok || !isObject {
+		return override
+	}
+
+	for key, value := range overrideObject {
+		if existing, ok := baseObject[key]; ok {
+			value = mergeIncluded(existing, value)
+		}
+		baseObject[key] = value
+	}
+	return baseObject
+}
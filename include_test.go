@@ -0,0 +1,128 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"testing/fstest"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Includes", func() {
+	var (
+		fsys fstest.MapFS
+	)
+
+	file := func(data string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(data)}
+	}
+
+	BeforeEach(func() {
+		fsys = fstest.MapFS{
+			"config.json": file(`{
+				"name": "service",
+				"database": {"$include": "common/db.json", "pool": {"max": 20}},
+				"servers": [{"$include": "common/server.yaml"}]
+			}`),
+			"common/db.json":     file(`{"host": "db", "pool": {"min": 1, "max": 10}, "tls": {"$include": "tls.toml"}}`),
+			"common/tls.toml":    file(`cert = "cert.pem"`),
+			"common/server.yaml": file("port: 8080\n"),
+		}
+	})
+
+	It("replaces includes relative to the including file", func() {
+		values, err := jsonstruct.LoadFile(fsys, "config.json")
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := json.Marshal(values)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"name": "service",
+			"database": {"host": "db", "pool": {"min": 1, "max": 20}, "tls": {"cert": "cert.pem"}},
+			"servers": [{"port": 8080}]
+		}`))
+	})
+
+	It("merges lists of includes in order", func() {
+		fsys["base.json"] = file(`{"a": 1, "b": {"c": 2, "d": 3}}`)
+		fsys["override.json"] = file(`{"b": {"d": 4}, "e": [5]}`)
+		fsys["list.json"] = file(`{"$include": ["base.json", "override.json"], "e": [6]}`)
+
+		values, err := jsonstruct.LoadFile(fsys, "list.json")
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := json.Marshal(values)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"a": 1, "b": {"c": 2, "d": 4}, "e": [6]}`))
+	})
+
+	It("includes values that are not objects", func() {
+		fsys["hosts.json"] = file(`["a", "b"]`)
+		fsys["list.json"] = file(`{"hosts": {"$include": "hosts.json"}}`)
+
+		values, err := jsonstruct.LoadFile(fsys, "list.json")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(values.StringList(".hosts")).To(Equal([]string{"a", "b"}))
+	})
+
+	It("resolves includes in parsed documents", func() {
+		values := jsonstruct.JSONStruct{"db": map[string]interface{}{"$include": "db.json"}}
+
+		Expect(values.ResolveIncludes(fsys, "common/app.json")).To(Succeed())
+
+		Expect(values.StringWithDefault(".db.host", "")).To(Equal("db"))
+		Expect(values.StringWithDefault(".db.tls.cert", "")).To(Equal("cert.pem"))
+	})
+
+	It("detects include cycles", func() {
+		fsys["a.json"] = file(`{"b": {"$include": "dir/b.json"}}`)
+		fsys["dir/b.json"] = file(`{"$include": "../a.json"}`)
+
+		_, err := jsonstruct.LoadFile(fsys, "a.json")
+		Expect(errors.Is(err, jsonstruct.ErrIncludeCycle)).To(BeTrue())
+		Expect(err).To(MatchError(".b: Including dir/b.json: .: Include cycle: a.json -> dir/b.json -> a.json"))
+	})
+
+	It("allows the same file to be included more than once", func() {
+		fsys["twice.json"] = file(`{"a": {"$include": "common/server.yaml"}, "b": {"$include": "common/server.yaml"}}`)
+
+		values, err := jsonstruct.LoadFile(fsys, "twice.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values.IntWithDefault(".a.port", 0)).To(Equal(8080))
+		Expect(values.IntWithDefault(".b.port", 0)).To(Equal(8080))
+	})
+
+	It("refuses to read outside of the file system", func() {
+		fsys["escape.json"] = file(`{"a": {"$include": "../secret.json"}}`)
+		_, err := jsonstruct.LoadFile(fsys, "escape.json")
+		Expect(errors.Is(err, jsonstruct.ErrIncludeOutsideRoot)).To(BeTrue())
+		Expect(err).To(MatchError(".a: ../secret.json: Include is outside of the root directory"))
+
+		fsys["absolute.json"] = file(`{"a": {"$include": "/etc/passwd"}}`)
+		_, err = jsonstruct.LoadFile(fsys, "absolute.json")
+		Expect(errors.Is(err, jsonstruct.ErrIncludeOutsideRoot)).To(BeTrue())
+	})
+
+	It("returns errors", func() {
+		fsys["missing.json"] = file(`{"a": {"$include": "nope.json"}}`)
+		_, err := jsonstruct.LoadFile(fsys, "missing.json")
+		Expect(errors.Is(err, fs.ErrNotExist)).To(BeTrue())
+
+		fsys["invalid.json"] = file(`{"a": {"$include": 1}}`)
+		_, err = jsonstruct.LoadFile(fsys, "invalid.json")
+		Expect(err).To(MatchError(".a: $include must be a file name or a list of file names"))
+
+		fsys["list.json"] = file(`["a"]`)
+		_, err = jsonstruct.LoadFile(fsys, "list.json")
+		Expect(err).To(MatchError("list.json: Value is not an object"))
+
+		fsys["broken.json"] = file(`{`)
+		_, err = jsonstruct.LoadFile(fsys, "broken.json")
+		Expect(err).To(MatchError("broken.json: unexpected end of JSON input"))
+	})
+})
@@ -3,6 +3,7 @@ package integration_tests_test
 import (
 	"encoding/json"
 	"io/ioutil"
+	"testing"
 	"time"
 
 	"github.com/myshkin5/jsonstruct"
@@ -92,8 +93,59 @@ var _ = Describe("JSONStruct", func() {
 		b.RecordValue("Wikipedia Sample Struct Run Duration (ns)", float64(wikipediaRunDuration.Nanoseconds()))
 		b.RecordValue("JSON Struct Run Duration (ns)", float64(jsonRunDuration.Nanoseconds()))
 	}, 10)
+
+	Measure("five value gets with compiled paths", func(b Benchmarker) {
+		jsonStruct := parseToJSONStruct(buffer)
+
+		// Prime any caches and verify the values once
+		readJSONStruct(jsonStruct)
+		readJSONStructWithPaths(jsonStruct)
+
+		start := time.Now()
+		for i := 0; i < 1000; i++ {
+			jsonStruct.String(".firstName")
+			jsonStruct.String(".lastName")
+			jsonStruct.Int(".age")
+			jsonStruct.String(".address.streetAddress")
+			jsonStruct.String(".address.postalCode")
+		}
+		dotPathRunDuration := time.Now().Sub(start)
+
+		start = time.Now()
+		for i := 0; i < 1000; i++ {
+			jsonStruct.StringAt(firstNamePath)
+			jsonStruct.StringAt(lastNamePath)
+			jsonStruct.IntAt(agePath)
+			jsonStruct.StringAt(streetAddressPath)
+			jsonStruct.StringAt(postalCodePath)
+		}
+		compiledPathRunDuration := time.Now().Sub(start)
+
+		Expect(compiledPathRunDuration).To(BeNumerically("<", dotPathRunDuration))
+
+		b.RecordValue("Dot Path Run Duration (ns)", float64(dotPathRunDuration.Nanoseconds()))
+		b.RecordValue("Compiled Path Run Duration (ns)", float64(compiledPathRunDuration.Nanoseconds()))
+	}, 10)
+
+	It("does not allocate when reading strings with compiled paths", func() {
+		jsonStruct := parseToJSONStruct(buffer)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			jsonStruct.StringAt(streetAddressPath)
+			jsonStruct.FindElementAt(agePath)
+		})
+		Expect(allocs).To(BeZero())
+	})
 })
 
+var (
+	firstNamePath     = jsonstruct.MustCompilePath(".firstName")
+	lastNamePath      = jsonstruct.MustCompilePath(".lastName")
+	agePath           = jsonstruct.MustCompilePath(".age")
+	streetAddressPath = jsonstruct.MustCompilePath(".address.streetAddress")
+	postalCodePath    = jsonstruct.MustCompilePath(".address.postalCode")
+)
+
 func loadFromFile(filename string) []byte {
 	buffer, err := ioutil.ReadFile(filename)
 	Expect(err).NotTo(HaveOccurred())
@@ -115,8 +167,10 @@ func parseToWikipediaSample(buffer []byte) WikipediaSample {
 }
 
 func parseAndReadJSONStruct(buffer []byte) {
-	jsonStruct := parseToJSONStruct(buffer)
+	readJSONStruct(parseToJSONStruct(buffer))
+}
 
+func readJSONStruct(jsonStruct jsonstruct.JSONStruct) {
 	firstName, ok := jsonStruct.String(".firstName")
 	Expect(ok).To(BeTrue())
 	Expect(firstName).To(Equal("John"))
@@ -134,6 +188,24 @@ func parseAndReadJSONStruct(buffer []byte) {
 	Expect(postalCode).To(Equal("10021-3100"))
 }
 
+func readJSONStructWithPaths(jsonStruct jsonstruct.JSONStruct) {
+	firstName, ok := jsonStruct.StringAt(firstNamePath)
+	Expect(ok).To(BeTrue())
+	Expect(firstName).To(Equal("John"))
+	lastName, ok := jsonStruct.StringAt(lastNamePath)
+	Expect(ok).To(BeTrue())
+	Expect(lastName).To(Equal("Smith"))
+	age, ok := jsonStruct.IntAt(agePath)
+	Expect(ok).To(BeTrue())
+	Expect(age).To(Equal(25))
+	streetAddress, ok := jsonStruct.StringAt(streetAddressPath)
+	Expect(ok).To(BeTrue())
+	Expect(streetAddress).To(Equal("21 2nd Street"))
+	postalCode, ok := jsonStruct.StringAt(postalCodePath)
+	Expect(ok).To(BeTrue())
+	Expect(postalCode).To(Equal("10021-3100"))
+}
+
 func parseAndReadWikipediaSample(buffer []byte) {
 	sample := parseToWikipediaSample(buffer)
 
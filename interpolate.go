@@ -0,0 +1,206 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrReferenceCycle = errors.New("Reference cycle")
+
+type InterpolateOptions struct {
+	// LookupEnv resolves ${env:NAME} references, os.LookupEnv if nil
+	LookupEnv func(name string) (string, bool)
+}
+
+// Interpolate replaces ${.dot.path} references to other values and
+// ${env:NAME} references to environment variables in every string value.
+// Referenced strings are interpolated first. A string that is exactly one
+// path reference is replaced by a copy of the referenced value, keeping its
+// type; otherwise referenced numbers and booleans are formatted as strings.
+// $${ is replaced by a literal ${. The document is left unchanged if any
+// reference cannot be resolved.
+func (s JSONStruct) Interpolate(opts InterpolateOptions) error {
+	i := &interpolator{
+		s:         s.DeepCopy(),
+		lookupEnv: opts.LookupEnv,
+		resolved:  make(map[string]bool),
+	}
+	if i.lookupEnv == nil {
+		i.lookupEnv = os.LookupEnv
+	}
+
+	for _, key := range sortedKeys(i.s) {
+		_, err := i.resolvePath([]string{key})
+		if err != nil {
+			return err
+		}
+	}
+
+	for key := range s {
+		delete(s, key)
+	}
+	for key, value := range i.s {
+		s[key] = value
+	}
+	return nil
+}
+
+type interpolator struct {
+	s         JSONStruct
+	lookupEnv func(string) (string, bool)
+	resolved  map[string]bool
+	stack     []string
+}
+
+func (i *interpolator) resolvePath(keys []string) (interface{}, error) {
+	path := "." + strings.Join(keys, ".")
+	value, ok := i.s.findElement(keys)
+	if !ok || i.resolved[path] {
+		return value, nil
+	}
+
+	for n, active := range i.stack {
+		if active == path {
+			cycle := append(i.stack[n:len(i.stack):len(i.stack)], path)
+			return nil, &PathError{
+				Path: i.stack[len(i.stack)-1],
+				Err:  fmt.Errorf("%w: %s", ErrReferenceCycle, strings.Join(cycle, " -> ")),
+			}
+		}
+	}
+
+	i.stack = append(i.stack, path)
+	value, err := i.resolveValue(path, keys, value)
+	i.stack = i.stack[:len(i.stack)-1]
+	if err != nil {
+		return nil, err
+	}
+
+	parent, lastKey, _ := i.s.findParentKeys(keys, nil)
+	parent[lastKey] = value
+	i.resolved[path] = true
+	return value, nil
+}
+
+// resolveValue interpolates value in place. keys is nil for values inside
+// lists, which cannot be referenced.
+func (i *interpolator) resolveValue(path string, keys []string, value interface{}) (interface{}, error) {
+	switch value := asObject(value).(type) {
+	case string:
+		return i.interpolate(path, value)
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			var err error
+			if keys != nil {
+				_, err = i.resolvePath(append(keys[:len(keys):len(keys)], key))
+			} else {
+				value[key], err = i.resolveValue(childPath(path, key), nil, value[key])
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case []interface{}:
+		for n, element := range value {
+			var err error
+			value[n], err = i.resolveValue(path+"["+strconv.Itoa(n)+"]", nil, element)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+func (i *interpolator) interpolate(path, value string) (interface{}, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var result strings.Builder
+	rest := value
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			result.WriteString(rest)
+			return result.String(), nil
+		}
+
+		if start > 0 && rest[start-1] == '$' {
+			result.WriteString(rest[:start-1] + "${")
+			rest = rest[start+2:]
+			continue
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, &PathError{Path: path, Err: fmt.Errorf("Unterminated reference in %q", value)}
+		}
+		end += start
+
+		reference := rest[start+2 : end]
+		resolved, err := i.resolveReference(path, reference)
+		if err != nil {
+			return nil, err
+		}
+
+		if rest == value && start == 0 && end == len(rest)-1 && strings.HasPrefix(reference, ".") {
+			return deepCopyValue(resolved), nil
+		}
+
+		str, ok := toString(resolved)
+		switch resolved := resolved.(type) {
+		case bool:
+			str, ok = strconv.FormatBool(resolved), true
+		case json.Number:
+			str, ok = string(resolved), true
+		}
+		if !ok {
+			return nil, &PathError{
+				Path: path,
+				Err:  fmt.Errorf("Cannot interpolate %s from ${%s} into a string", typeName(resolved), reference),
+			}
+		}
+
+		result.WriteString(rest[:start] + str)
+		rest = rest[end+1:]
+	}
+}
+
+func (i *interpolator) resolveReference(path, reference string) (interface{}, error) {
+	if name, ok := strings.CutPrefix(reference, "env:"); ok {
+		value, ok := i.lookupEnv(name)
+		if !ok {
+			return nil, &PathError{Path: path, Err: fmt.Errorf("Environment variable %s is not set", name)}
+		}
+		return value, nil
+	}
+
+	keys, err := splitPath(reference)
+	if err != nil {
+		return nil, &PathError{Path: path, Err: fmt.Errorf("Unsupported reference ${%s}", reference)}
+	}
+
+	if _, ok := i.s.findElement(keys); !ok {
+		return nil, &PathError{Path: path, Err: fmt.Errorf("Reference ${%s}: %w", reference, ErrValueNotFound)}
+	}
+
+	return i.resolvePath(keys)
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
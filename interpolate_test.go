@@ -0,0 +1,142 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interpolate", func() {
+	var (
+		values jsonstruct.JSONStruct
+		opts   jsonstruct.InterpolateOptions
+	)
+
+	parse := func(data string) {
+		values = nil
+		Expect(json.Unmarshal([]byte(data), &values)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		opts = jsonstruct.InterpolateOptions{
+			LookupEnv: func(name string) (string, bool) {
+				if name == "HOME" {
+					return "/home/user", true
+				}
+				return "", false
+			},
+		}
+	})
+
+	It("resolves path references", func() {
+		parse(`{
+			"server": {"host": "example.com", "port": 8080, "tls": true},
+			"urls": {
+				"api": "${.urls.base}/api",
+				"base": "https://${.server.host}:${.server.port}"
+			},
+			"secure": "tls=${.server.tls}",
+			"mirrors": ["${.urls.api}/v1", {"url": "${.urls.api}/v2"}]
+		}`)
+
+		Expect(values.Interpolate(opts)).To(Succeed())
+
+		Expect(values.StringWithDefault(".urls.base", "")).To(Equal("https://example.com:8080"))
+		Expect(values.StringWithDefault(".urls.api", "")).To(Equal("https://example.com:8080/api"))
+		Expect(values.StringWithDefault(".secure", "")).To(Equal("tls=true"))
+		list, _ := values.List(".mirrors")
+		Expect(list).To(Equal([]interface{}{
+			"https://example.com:8080/api/v1",
+			map[string]interface{}{"url": "https://example.com:8080/api/v2"},
+		}))
+	})
+
+	It("keeps the type of whole value references", func() {
+		parse(`{
+			"defaults": {"port": 8080, "hosts": ["a", "${.name}"]},
+			"name": "b",
+			"port": "${.defaults.port}",
+			"copy": "${.defaults}"
+		}`)
+
+		Expect(values.Interpolate(opts)).To(Succeed())
+
+		port, _ := values.FindElement(".port")
+		Expect(port).To(Equal(8080.0))
+		copied, _ := values.FindElement(".copy")
+		Expect(copied).To(Equal(map[string]interface{}{
+			"port":  8080.0,
+			"hosts": []interface{}{"a", "b"},
+		}))
+
+		Expect(values.SetInt(".copy.port", 9090)).To(Succeed())
+		Expect(values.IntWithDefault(".defaults.port", 0)).To(Equal(8080))
+	})
+
+	It("formats json.Numbers as written", func() {
+		decoder := json.NewDecoder(strings.NewReader(`{"port": 8080, "big": 12345678901234567891, "url": "x:${.port}/${.big}"}`))
+		decoder.UseNumber()
+		values = nil
+		Expect(decoder.Decode(&values)).To(Succeed())
+
+		Expect(values.Interpolate(opts)).To(Succeed())
+
+		Expect(values.StringWithDefault(".url", "")).To(Equal("x:8080/12345678901234567891"))
+	})
+
+	It("resolves environment variables", func() {
+		parse(`{"dir": "${env:HOME}/.config"}`)
+
+		Expect(values.Interpolate(opts)).To(Succeed())
+
+		Expect(values.StringWithDefault(".dir", "")).To(Equal("/home/user/.config"))
+	})
+
+	It("supports escaping", func() {
+		parse(`{"a": "x", "literal": "$${.a} and $${env:HOME} but ${.a}"}`)
+
+		Expect(values.Interpolate(opts)).To(Succeed())
+
+		Expect(values.StringWithDefault(".literal", "")).To(Equal("${.a} and ${env:HOME} but x"))
+	})
+
+	It("reports reference cycles with the full cycle", func() {
+		parse(`{"a": "${.b.c}", "b": {"c": "x${.d}"}, "d": "${.a}", "e": "unchanged"}`)
+
+		err := values.Interpolate(opts)
+		Expect(errors.Is(err, jsonstruct.ErrReferenceCycle)).To(BeTrue())
+		Expect(err).To(MatchError(".d: Reference cycle: .a -> .b.c -> .d -> .a"))
+
+		Expect(values.StringWithDefault(".a", "")).To(Equal("${.b.c}"))
+	})
+
+	It("reports self references to parents as cycles", func() {
+		parse(`{"a": {"b": "${.a}"}}`)
+
+		Expect(values.Interpolate(opts)).To(MatchError(".a.b: Reference cycle: .a -> .a.b -> .a"))
+	})
+
+	It("returns errors for unresolvable references", func() {
+		parse(`{"a": "${.missing}"}`)
+		err := values.Interpolate(opts)
+		Expect(errors.Is(err, jsonstruct.ErrValueNotFound)).To(BeTrue())
+		Expect(err).To(MatchError(".a: Reference ${.missing}: Value not found"))
+
+		parse(`{"a": "${env:MISSING}"}`)
+		Expect(values.Interpolate(opts)).To(MatchError(".a: Environment variable MISSING is not set"))
+
+		parse(`{"a": "${missing}"}`)
+		Expect(values.Interpolate(opts)).To(MatchError(".a: Unsupported reference ${missing}"))
+
+		parse(`{"a": "${.b"}`)
+		Expect(values.Interpolate(opts)).To(MatchError(`.a: Unterminated reference in "${.b"`))
+
+		parse(`{"a": "x${.b}", "b": {"c": 1}}`)
+		Expect(values.Interpolate(opts)).To(MatchError(".a: Cannot interpolate object from ${.b} into a string"))
+	})
+})
@@ -0,0 +1,87 @@
+package jsonstruct
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+func (s JSONStruct) Keys(dotPath string) ([]string, bool) {
+	object, ok := s.object(dotPath)
+	if !ok {
+		return nil, false
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, true
+}
+
+// Len returns the number of keys in an object, elements in a list or
+// characters in a string.
+func (s JSONStruct) Len(dotPath string) (int, bool) {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return 0, false
+	}
+
+	switch value := asObject(value).(type) {
+	case map[string]interface{}:
+		return len(value), true
+	case []interface{}:
+		return len(value), true
+	case string:
+		return utf8.RuneCountInString(value), true
+	default:
+		return 0, false
+	}
+}
+
+func (s JSONStruct) StringMap(dotPath string) (map[string]string, bool) {
+	object, ok := s.object(dotPath)
+	if !ok {
+		return nil, false
+	}
+
+	result := make(map[string]string, len(object))
+	for key, value := range object {
+		str, ok := toString(value)
+		if !ok {
+			return nil, false
+		}
+		result[key] = str
+	}
+
+	return result, true
+}
+
+func (s JSONStruct) IntMap(dotPath string) (map[string]int, bool) {
+	object, ok := s.object(dotPath)
+	if !ok {
+		return nil, false
+	}
+
+	result := make(map[string]int, len(object))
+	for key, value := range object {
+		i, ok := toInt(value)
+		if !ok {
+			return nil, false
+		}
+		result[key] = i
+	}
+
+	return result, true
+}
+
+func (s JSONStruct) object(dotPath string) (map[string]interface{}, bool) {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return nil, false
+	}
+
+	object, ok := asObject(value).(map[string]interface{})
+	return object, ok
+}
@@ -0,0 +1,107 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Introspection", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(json.Unmarshal([]byte(`{
+			"services": {
+				"web": { "port": 80 },
+				"api": { "port": 8080 },
+				"db": { "port": 5432 }
+			},
+			"hosts": { "web": "web.local", "api": "api.local" },
+			"ports": { "web": 80, "api": 8080 },
+			"children": ["Catherine", "Thomas"],
+			"name": "Jürgen",
+			"age": 25
+		}`), &values)).To(Succeed())
+	})
+
+	Describe("Keys()", func() {
+		It("returns sorted keys", func() {
+			keys, ok := values.Keys(".services")
+			Expect(ok).To(BeTrue())
+			Expect(keys).To(Equal([]string{"api", "db", "web"}))
+		})
+
+		It("returns not ok for missing values and non-objects", func() {
+			_, ok := values.Keys(".missing")
+			Expect(ok).To(BeFalse())
+			_, ok = values.Keys(".children")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("supports nested JSONStructs", func() {
+			values["sub"] = jsonstruct.JSONStruct{"b": 1, "a": 2}
+			keys, ok := values.Keys(".sub")
+			Expect(ok).To(BeTrue())
+			Expect(keys).To(Equal([]string{"a", "b"}))
+		})
+	})
+
+	Describe("Len()", func() {
+		It("returns the length of objects, lists and strings", func() {
+			for dotPath, expected := range map[string]int{
+				".services": 3,
+				".children": 2,
+				".name":     6,
+			} {
+				length, ok := values.Len(dotPath)
+				Expect(ok).To(BeTrue())
+				Expect(length).To(Equal(expected))
+			}
+		})
+
+		It("returns not ok for missing values and other types", func() {
+			_, ok := values.Len(".missing")
+			Expect(ok).To(BeFalse())
+			_, ok = values.Len(".age")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("StringMap()", func() {
+		It("returns an object as a map of strings", func() {
+			hosts, ok := values.StringMap(".hosts")
+			Expect(ok).To(BeTrue())
+			Expect(hosts).To(Equal(map[string]string{"web": "web.local", "api": "api.local"}))
+
+			ports, ok := values.StringMap(".ports")
+			Expect(ok).To(BeTrue())
+			Expect(ports).To(Equal(map[string]string{"web": "80", "api": "8080"}))
+		})
+
+		It("returns not ok when a value can't be converted", func() {
+			_, ok := values.StringMap(".services")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("IntMap()", func() {
+		It("returns an object as a map of ints", func() {
+			ports, ok := values.IntMap(".ports")
+			Expect(ok).To(BeTrue())
+			Expect(ports).To(Equal(map[string]int{"web": 80, "api": 8080}))
+		})
+
+		It("returns not ok when a value can't be converted", func() {
+			_, ok := values.IntMap(".hosts")
+			Expect(ok).To(BeFalse())
+			_, ok = values.IntMap(".missing")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
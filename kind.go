@@ -0,0 +1,71 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Kind is the JSON type of a value regardless of the Go type used to hold it.
+type Kind int
+
+const (
+	Missing Kind = iota
+	Null
+	Bool
+	Number
+	String
+	Object
+	Array
+)
+
+var kindNames = []string{
+	Missing: "missing",
+	Null:    "null",
+	Bool:    "boolean",
+	Number:  "number",
+	String:  "string",
+	Object:  "object",
+	Array:   "array",
+}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "unknown"
+	}
+	return kindNames[k]
+}
+
+func (s JSONStruct) TypeOf(dotPath string) Kind {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return Missing
+	}
+
+	return kindOf(value)
+}
+
+// kindOf returns Missing for Go values that have no JSON representation
+func kindOf(value interface{}) Kind {
+	switch value.(type) {
+	case nil:
+		return Null
+	case bool:
+		return Bool
+	case string:
+		return String
+	case float64, int, int64, json.Number:
+		return Number
+	case map[string]interface{}, JSONStruct:
+		return Object
+	case []interface{}:
+		return Array
+	}
+
+	// Classify other Go values by the JSON they serialize to, e.g. a
+	// time.Time is a string and a struct is an object
+	normalized, err := normalize("", reflect.ValueOf(value))
+	if err != nil {
+		return Missing
+	}
+	return kindOf(normalized)
+}
@@ -0,0 +1,74 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kind", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(json.Unmarshal([]byte(`{
+			"null": null,
+			"bool": true,
+			"float": 1.5,
+			"string": "x",
+			"object": { "a": 1 },
+			"array": [1, 2]
+		}`), &values)).To(Succeed())
+		Expect(values.SetInt(".int", 2)).To(Succeed())
+		values["jsonStruct"] = jsonstruct.New()
+		values["strings"] = []string{"a"}
+		values["time"] = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		values["bytes"] = []byte("abc")
+		values["struct"] = struct{ A int }{A: 1}
+		values["nilPointer"] = (*int)(nil)
+		values["channel"] = make(chan int)
+	})
+
+	DescribeTable("TypeOf()", func(dotPath string, expected jsonstruct.Kind) {
+		Expect(values.TypeOf(dotPath)).To(Equal(expected))
+	},
+		Entry("missing", ".missing", jsonstruct.Missing),
+		Entry("null", ".null", jsonstruct.Null),
+		Entry("bool", ".bool", jsonstruct.Bool),
+		Entry("parsed number", ".float", jsonstruct.Number),
+		Entry("set number", ".int", jsonstruct.Number),
+		Entry("string", ".string", jsonstruct.String),
+		Entry("object", ".object", jsonstruct.Object),
+		Entry("nested JSONStruct", ".jsonStruct", jsonstruct.Object),
+		Entry("array", ".array", jsonstruct.Array),
+		Entry("typed slice", ".strings", jsonstruct.Array),
+		Entry("time serialized as a string", ".time", jsonstruct.String),
+		Entry("bytes serialized as a base64 string", ".bytes", jsonstruct.String),
+		Entry("struct", ".struct", jsonstruct.Object),
+		Entry("nil pointer", ".nilPointer", jsonstruct.Null),
+		Entry("value without a JSON form", ".channel", jsonstruct.Missing),
+	)
+
+	It("classifies json.Number values parsed with UseNumber", func() {
+		decoder := json.NewDecoder(strings.NewReader(`{"n": 12345678901234567890}`))
+		decoder.UseNumber()
+		Expect(decoder.Decode(&values)).To(Succeed())
+
+		Expect(values.TypeOf(".n")).To(Equal(jsonstruct.Number))
+	})
+
+	It("has JSON names", func() {
+		Expect(jsonstruct.Bool.String()).To(Equal("boolean"))
+		Expect(jsonstruct.Array.String()).To(Equal("array"))
+		Expect(jsonstruct.Missing.String()).To(Equal("missing"))
+		Expect(jsonstruct.Kind(99).String()).To(Equal("unknown"))
+	})
+})
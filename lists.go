@@ -0,0 +1,150 @@
+package jsonstruct
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+func (s JSONStruct) StringList(dotPath string) ([]string, error) {
+	return typedList(s, dotPath, func(value interface{}) (string, error) {
+		str, ok := toString(value)
+		if !ok {
+			return "", errWrongType
+		}
+		return str, nil
+	})
+}
+
+func (s JSONStruct) StringListWithDefault(dotPath string, defaultValue []string) ([]string, error) {
+	value, err := s.StringList(dotPath)
+	return listWithDefault(value, err, defaultValue)
+}
+
+func (s JSONStruct) IntList(dotPath string) ([]int, error) {
+	return typedList(s, dotPath, func(value interface{}) (int, error) {
+		// Unlike Int, fractions are rejected rather than truncated
+		var i int
+		if convert("", value, reflect.ValueOf(&i).Elem()) != nil {
+			return 0, errWrongType
+		}
+		return i, nil
+	})
+}
+
+func (s JSONStruct) IntListWithDefault(dotPath string, defaultValue []int) ([]int, error) {
+	value, err := s.IntList(dotPath)
+	return listWithDefault(value, err, defaultValue)
+}
+
+func (s JSONStruct) Float64List(dotPath string) ([]float64, error) {
+	return typedList(s, dotPath, func(value interface{}) (float64, error) {
+		f, ok := toFloat64(value)
+		if !ok {
+			return 0, errWrongType
+		}
+		return f, nil
+	})
+}
+
+func (s JSONStruct) Float64ListWithDefault(dotPath string, defaultValue []float64) ([]float64, error) {
+	value, err := s.Float64List(dotPath)
+	return listWithDefault(value, err, defaultValue)
+}
+
+func (s JSONStruct) BoolList(dotPath string) ([]bool, error) {
+	return typedList(s, dotPath, func(value interface{}) (bool, error) {
+		b, ok := value.(bool)
+		if !ok {
+			return false, errWrongType
+		}
+		return b, nil
+	})
+}
+
+func (s JSONStruct) BoolListWithDefault(dotPath string, defaultValue []bool) ([]bool, error) {
+	value, err := s.BoolList(dotPath)
+	return listWithDefault(value, err, defaultValue)
+}
+
+func (s JSONStruct) DurationList(dotPath string) ([]time.Duration, error) {
+	return typedList(s, dotPath, func(value interface{}) (time.Duration, error) {
+		return toDuration(value, time.Second)
+	})
+}
+
+func (s JSONStruct) DurationListWithDefault(dotPath string, defaultValue []time.Duration) ([]time.Duration, error) {
+	value, err := s.DurationList(dotPath)
+	return listWithDefault(value, err, defaultValue)
+}
+
+func (s JSONStruct) SetStringList(dotPath string, value []string, opts ...SetOption) error {
+	return s.SetList(dotPath, untypedList(value, func(str string) interface{} { return str }), opts...)
+}
+
+func (s JSONStruct) SetIntList(dotPath string, value []int, opts ...SetOption) error {
+	return s.SetList(dotPath, untypedList(value, func(i int) interface{} { return i }), opts...)
+}
+
+func (s JSONStruct) SetFloat64List(dotPath string, value []float64, opts ...SetOption) error {
+	return s.SetList(dotPath, untypedList(value, func(f float64) interface{} { return f }), opts...)
+}
+
+func (s JSONStruct) SetBoolList(dotPath string, value []bool, opts ...SetOption) error {
+	return s.SetList(dotPath, untypedList(value, func(b bool) interface{} { return b }), opts...)
+}
+
+func (s JSONStruct) SetDurationList(dotPath string, value []time.Duration, opts ...SetOption) error {
+	return s.SetList(dotPath, untypedList(value, func(d time.Duration) interface{} { return d.String() }), opts...)
+}
+
+// errWrongType is replaced by a conversion error naming the element's path
+// and type before it reaches the caller
+var errWrongType = errors.New("Wrong type")
+
+func typedList[T any](s JSONStruct, dotPath string, convert func(interface{}) (T, error)) ([]T, error) {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, conversionError(dotPath, value, reflect.TypeOf([]T{}))
+	}
+
+	result := make([]T, len(list))
+	for i, element := range list {
+		converted, err := convert(element)
+		if err != nil {
+			elementPath := dotPath + "[" + strconv.Itoa(i) + "]"
+			if err == errWrongType {
+				return nil, conversionError(elementPath, element, reflect.TypeOf(converted))
+			}
+			return nil, &PathError{Path: elementPath, Err: err}
+		}
+		result[i] = converted
+	}
+
+	return result, nil
+}
+
+func listWithDefault[T any](value []T, err error, defaultValue []T) ([]T, error) {
+	switch {
+	case err == ErrValueNotFound:
+		return defaultValue, nil
+	case err != nil:
+		return nil, err
+	default:
+		return value, nil
+	}
+}
+
+func untypedList[T any](value []T, convert func(T) interface{}) []interface{} {
+	list := make([]interface{}, len(value))
+	for i, element := range value {
+		list[i] = convert(element)
+	}
+	return list
+}
@@ -0,0 +1,121 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lists", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(json.Unmarshal([]byte(`{
+			"children": ["Catherine", "Thomas", "Trevor"],
+			"ages": [5, 7, 9],
+			"weights": [20.5, 30],
+			"flags": [true, false],
+			"timeouts": ["1s", "2m"],
+			"mixed": ["a", true, 3],
+			"notAList": "x"
+		}`), &values)).To(Succeed())
+	})
+
+	It("converts lists element-wise", func() {
+		Expect(values.StringList(".children")).To(Equal([]string{"Catherine", "Thomas", "Trevor"}))
+		Expect(values.IntList(".ages")).To(Equal([]int{5, 7, 9}))
+		Expect(values.Float64List(".weights")).To(Equal([]float64{20.5, 30}))
+		Expect(values.BoolList(".flags")).To(Equal([]bool{true, false}))
+		Expect(values.DurationList(".timeouts")).To(Equal([]time.Duration{time.Second, 2 * time.Minute}))
+	})
+
+	It("coerces numbers into strings like String()", func() {
+		Expect(values.StringList(".ages")).To(Equal([]string{"5", "7", "9"}))
+	})
+
+	It("returns a not found error when the value doesn't exist", func() {
+		_, err := values.StringList(".missing")
+		Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		_, err = values.IntList(".missing")
+		Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		_, err = values.Float64List(".missing")
+		Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		_, err = values.BoolList(".missing")
+		Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		_, err = values.DurationList(".missing")
+		Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+	})
+
+	It("returns an error when the value isn't a list", func() {
+		_, err := values.StringList(".notAList")
+		Expect(err).To(MatchError(".notAList: Cannot convert string to []string"))
+	})
+
+	It("reports the index of the first bad element", func() {
+		_, err := values.StringList(".mixed")
+		Expect(err).To(MatchError(".mixed[1]: Cannot convert boolean to string"))
+
+		_, err = values.IntList(".mixed")
+		Expect(err).To(MatchError(".mixed[0]: Cannot convert string to int"))
+
+		Expect(values.SetList(".fractions", []interface{}{1.9, 2})).To(Succeed())
+		_, err = values.IntList(".fractions")
+		Expect(err).To(MatchError(".fractions[0]: Cannot convert number to int"))
+
+		_, err = values.BoolList(".mixed")
+		Expect(err).To(MatchError(".mixed[0]: Cannot convert string to bool"))
+
+		_, err = values.DurationList(".children")
+		Expect(err).To(MatchError(HavePrefix(".children[0]: ")))
+	})
+
+	Describe("WithDefault variants", func() {
+		It("return the default value when a value isn't found", func() {
+			Expect(values.StringListWithDefault(".missing", []string{"a"})).To(Equal([]string{"a"}))
+			Expect(values.IntListWithDefault(".missing", []int{1})).To(Equal([]int{1}))
+			Expect(values.Float64ListWithDefault(".missing", []float64{1.5})).To(Equal([]float64{1.5}))
+			Expect(values.BoolListWithDefault(".missing", []bool{true})).To(Equal([]bool{true}))
+			Expect(values.DurationListWithDefault(".missing", []time.Duration{time.Hour})).To(Equal([]time.Duration{time.Hour}))
+		})
+
+		It("return the non-default value when a value is found", func() {
+			Expect(values.IntListWithDefault(".ages", []int{1})).To(Equal([]int{5, 7, 9}))
+		})
+
+		It("return conversion errors", func() {
+			_, err := values.IntListWithDefault(".mixed", []int{1})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("setters", func() {
+		It("set lists that can be read back", func() {
+			values = jsonstruct.New()
+
+			Expect(values.SetStringList(".strings", []string{"a", "b"})).To(Succeed())
+			Expect(values.SetIntList(".ints", []int{1, 2})).To(Succeed())
+			Expect(values.SetFloat64List(".floats", []float64{1.5})).To(Succeed())
+			Expect(values.SetBoolList(".bools", []bool{true})).To(Succeed())
+			Expect(values.SetDurationList(".durations", []time.Duration{time.Second})).To(Succeed())
+
+			data, err := json.Marshal(values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{
+				"strings": ["a", "b"],
+				"ints": [1, 2],
+				"floats": [1.5],
+				"bools": [true],
+				"durations": ["1s"]
+			}`))
+
+			Expect(values.DurationList(".durations")).To(Equal([]time.Duration{time.Second}))
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package jsonstruct
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+func (s JSONStruct) MustString(dotPath string) string {
+	value, ok := s.String(dotPath)
+	if !ok {
+		panic(s.mustError(dotPath, reflect.TypeOf("")))
+	}
+
+	return value
+}
+
+func (s JSONStruct) MustInt(dotPath string) int {
+	value, ok := s.Int(dotPath)
+	if !ok {
+		panic(s.mustError(dotPath, reflect.TypeOf(0)))
+	}
+
+	return value
+}
+
+func (s JSONStruct) MustDuration(dotPath string) time.Duration {
+	value, err := s.Duration(dotPath)
+	if err != nil {
+		var pathErr *PathError
+		if !errors.As(err, &pathErr) {
+			err = &PathError{Path: dotPath, Err: err}
+		}
+		panic(err)
+	}
+
+	return value
+}
+
+func (s JSONStruct) MustList(dotPath string) []interface{} {
+	value, ok := s.List(dotPath)
+	if !ok {
+		panic(s.mustError(dotPath, reflect.TypeOf([]interface{}{})))
+	}
+
+	return value
+}
+
+func (s JSONStruct) MustFindElement(dotPath string) interface{} {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		panic(&PathError{Path: dotPath, Err: ErrValueNotFound})
+	}
+
+	return value
+}
+
+// Require checks that every path is present and returns an error listing all
+// of the missing paths.
+func (s JSONStruct) Require(dotPaths ...string) error {
+	var errs []error
+	for _, dotPath := range dotPaths {
+		if _, ok := s.FindElement(dotPath); !ok {
+			errs = append(errs, &PathError{Path: dotPath, Err: ErrValueNotFound})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s JSONStruct) mustError(dotPath string, t reflect.Type) error {
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return &PathError{Path: dotPath, Err: ErrValueNotFound}
+	}
+
+	return conversionError(dotPath, value, t)
+}
@@ -0,0 +1,77 @@
+package jsonstruct_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Must", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(values.SetString(".server.host", "localhost")).To(Succeed())
+		Expect(values.SetInt(".server.port", 8080)).To(Succeed())
+		Expect(values.SetDuration(".server.timeout", 5*time.Second)).To(Succeed())
+		Expect(values.SetList(".server.aliases", []interface{}{"a"})).To(Succeed())
+		Expect(values.SetString(".server.bad-timeout", "soon")).To(Succeed())
+	})
+
+	panicValue := func(fn func()) (recovered interface{}) {
+		defer func() {
+			recovered = recover()
+		}()
+		fn()
+		return nil
+	}
+
+	It("returns values that are present", func() {
+		Expect(values.MustString(".server.host")).To(Equal("localhost"))
+		Expect(values.MustInt(".server.port")).To(Equal(8080))
+		Expect(values.MustDuration(".server.timeout")).To(Equal(5 * time.Second))
+		Expect(values.MustList(".server.aliases")).To(Equal([]interface{}{"a"}))
+		Expect(values.MustFindElement(".server.port")).To(Equal(8080))
+	})
+
+	It("panics with a path error when a value is missing", func() {
+		recovered := panicValue(func() { values.MustString(".server.missing") })
+
+		pathErr, ok := recovered.(*jsonstruct.PathError)
+		Expect(ok).To(BeTrue())
+		Expect(pathErr.Path).To(Equal(".server.missing"))
+		Expect(pathErr.Err).To(Equal(jsonstruct.ErrValueNotFound))
+		Expect(pathErr.Error()).To(Equal(".server.missing: Value not found"))
+
+		Expect(panicValue(func() { values.MustInt(".missing") })).To(BeAssignableToTypeOf(&jsonstruct.PathError{}))
+		Expect(panicValue(func() { values.MustDuration(".missing") })).To(BeAssignableToTypeOf(&jsonstruct.PathError{}))
+		Expect(panicValue(func() { values.MustList(".missing") })).To(BeAssignableToTypeOf(&jsonstruct.PathError{}))
+		Expect(panicValue(func() { values.MustFindElement(".missing") })).To(BeAssignableToTypeOf(&jsonstruct.PathError{}))
+	})
+
+	It("panics with a path error when a value has the wrong type", func() {
+		Expect(panicValue(func() { values.MustInt(".server.host") })).To(MatchError(".server.host: Cannot convert string to int"))
+		Expect(panicValue(func() { values.MustList(".server.port") })).To(MatchError(".server.port: Cannot convert number to []interface {}"))
+		Expect(panicValue(func() { values.MustDuration(".server.bad-timeout") })).To(MatchError(HavePrefix(".server.bad-timeout: ")))
+		Expect(panicValue(func() { values.MustDuration(".server.aliases") })).
+			To(MatchError(".server.aliases: Cannot convert array to time.Duration"))
+	})
+
+	Describe("Require()", func() {
+		It("succeeds when all paths are present", func() {
+			Expect(values.Require(".server.host", ".server.port")).To(Succeed())
+		})
+
+		It("reports all missing paths", func() {
+			err := values.Require(".server.host", ".server.user", ".database.name")
+			Expect(err).To(MatchError(".server.user: Value not found\n.database.name: Value not found"))
+			Expect(errors.Is(err, jsonstruct.ErrValueNotFound)).To(BeTrue())
+		})
+	})
+})
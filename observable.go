@@ -0,0 +1,280 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type ChangeFunc func(dotPath string, oldValue, newValue interface{})
+
+// ObservableJSONStruct calls registered ChangeFuncs whenever one of its
+// setters (or Delete) changes a value. Like JSONStruct it is not safe for
+// concurrent use.
+//
+// Patterns are dot paths where "*" matches any single key and "**" matches
+// any number of keys, e.g. ".services.*.port" or ".database.**". Patterns are
+// matched against the path passed to the setter, so replacing an object does
+// not notify observers of the paths inside it. Missing values are reported as
+// nil.
+type ObservableJSONStruct struct {
+	s          JSONStruct
+	observers  []observer
+	batchDepth int
+	pending    []change
+}
+
+type observer struct {
+	pattern []string
+	fn      ChangeFunc
+}
+
+type change struct {
+	keys     []string
+	oldValue interface{}
+	// undo restores everything the change replaced, which may be a parent
+	// of keys that was missing or (when forced) not an object
+	undo undoEntry
+}
+
+func NewObservable(s JSONStruct) *ObservableJSONStruct {
+	if s == nil {
+		s = New()
+	}
+	return &ObservableJSONStruct{s: s}
+}
+
+func (o *ObservableJSONStruct) OnChange(pattern string, fn ChangeFunc) error {
+	keys, err := splitPath(pattern)
+	if err != nil {
+		return err
+	}
+
+	o.observers = append(o.observers, observer{pattern: keys, fn: fn})
+	return nil
+}
+
+// Batch defers notifications until fn returns. Each observer is then called
+// at most once: with the changed path when only one of its matching paths
+// changed, or otherwise with the closest common parent of the changed paths
+// and copies of that parent from before and after the batch. Changes made
+// before fn fails are kept and notified.
+func (o *ObservableJSONStruct) Batch(fn func() error) error {
+	o.batchDepth++
+	defer func() {
+		o.batchDepth--
+		if o.batchDepth == 0 {
+			pending := o.pending
+			o.pending = nil
+			o.notifyBatch(pending)
+		}
+	}()
+
+	return fn()
+}
+
+func (o *ObservableJSONStruct) SetString(dotPath, value string, opts ...SetOption) error {
+	return o.set(dotPath, func() error { return o.s.SetString(dotPath, value, opts...) })
+}
+
+func (o *ObservableJSONStruct) SetInt(dotPath string, value int, opts ...SetOption) error {
+	return o.set(dotPath, func() error { return o.s.SetInt(dotPath, value, opts...) })
+}
+
+func (o *ObservableJSONStruct) SetDuration(dotPath string, value time.Duration, opts ...SetOption) error {
+	return o.set(dotPath, func() error { return o.s.SetDuration(dotPath, value, opts...) })
+}
+
+func (o *ObservableJSONStruct) SetList(dotPath string, value []interface{}, opts ...SetOption) error {
+	return o.set(dotPath, func() error { return o.s.SetList(dotPath, deepCopyValue(value).([]interface{}), opts...) })
+}
+
+func (o *ObservableJSONStruct) Delete(dotPath string) bool {
+	deleted := false
+	o.set(dotPath, func() error {
+		deleted = o.s.Delete(dotPath)
+		return nil
+	})
+	return deleted
+}
+
+func (o *ObservableJSONStruct) set(dotPath string, apply func() error) error {
+	keys, err := splitPath(dotPath)
+	if err != nil {
+		return err
+	}
+
+	oldValue, _ := o.s.findElement(keys)
+	undo := o.s.undoEntry(keys)
+
+	err = apply()
+	if err != nil {
+		return err
+	}
+
+	c := change{keys: keys, oldValue: oldValue, undo: undo}
+	if o.batchDepth > 0 {
+		o.pending = append(o.pending, c)
+	} else {
+		o.notifyBatch([]change{c})
+	}
+	return nil
+}
+
+func (o *ObservableJSONStruct) notifyBatch(changes []change) {
+	for _, obs := range o.observers {
+		var matching []change
+		for _, c := range changes {
+			if matchPattern(obs.pattern, c.keys) {
+				matching = append(matching, c)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		keys := commonPrefix(matching)
+		var newValue interface{} = map[string]interface{}(o.s)
+		if len(keys) > 0 {
+			newValue, _ = o.s.findElement(keys)
+		}
+		oldValue := o.oldValue(keys, newValue, matching)
+		if !allSameKeys(matching) {
+			newValue = deepCopyValue(newValue)
+		}
+		if jsonEqual(oldValue, newValue) {
+			continue
+		}
+
+		obs.fn(keysToPath(keys), oldValue, newValue)
+	}
+}
+
+// oldValue rebuilds the value at keys from before the changes by undoing
+// them, newest first, on a copy of its current value.
+func (o *ObservableJSONStruct) oldValue(keys []string, newValue interface{}, changes []change) interface{} {
+	if allSameKeys(changes) {
+		return changes[0].oldValue
+	}
+
+	old := deepCopyValue(newValue)
+	for i := len(changes) - 1; i >= 0; i-- {
+		undo := changes[i].undo
+		if len(undo.keys) <= len(keys) {
+			// The value at keys itself was replaced. A shorter undo path
+			// means a parent was missing or not an object, so there was no
+			// value at keys before.
+			old = nil
+			if undo.existed && len(undo.keys) == len(keys) {
+				old = deepCopyValue(undo.oldValue)
+			}
+			continue
+		}
+
+		object, ok := asObject(old).(map[string]interface{})
+		if !ok {
+			object = make(map[string]interface{})
+			old = object
+		}
+		relative := undo.keys[len(keys):]
+		if undo.existed {
+			parent, lastKey, _ := JSONStruct(object).findParentKeys(relative, []SetOption{Force})
+			parent[lastKey] = deepCopyValue(undo.oldValue)
+		} else {
+			JSONStruct(object).deleteKeys(relative)
+		}
+	}
+	return old
+}
+
+func allSameKeys(changes []change) bool {
+	for _, c := range changes[1:] {
+		if len(c.keys) != len(changes[0].keys) {
+			return false
+		}
+		for i := range c.keys {
+			if c.keys[i] != changes[0].keys[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func commonPrefix(changes []change) []string {
+	prefix := changes[0].keys
+	for _, c := range changes[1:] {
+		n := 0
+		for n < len(prefix) && n < len(c.keys) && prefix[n] == c.keys[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	// Two distinct paths that share every key but the last still need their
+	// parent as the common value
+	if !allSameKeys(changes) && len(prefix) == len(changes[0].keys) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
+}
+
+func matchPattern(pattern, keys []string) bool {
+	if len(pattern) == 0 {
+		return len(keys) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(keys); i++ {
+			if matchPattern(pattern[1:], keys[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(keys) == 0 || pattern[0] != "*" && pattern[0] != keys[0] {
+		return false
+	}
+	return matchPattern(pattern[1:], keys[1:])
+}
+
+func keysToPath(keys []string) string {
+	return "." + strings.Join(keys, ".")
+}
+
+func (o *ObservableJSONStruct) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.s)
+}
+
+func (o *ObservableJSONStruct) String(dotPath string) (string, bool) {
+	return o.s.String(dotPath)
+}
+
+func (o *ObservableJSONStruct) StringWithDefault(dotPath, defaultValue string) string {
+	return o.s.StringWithDefault(dotPath, defaultValue)
+}
+
+func (o *ObservableJSONStruct) Int(dotPath string) (int, bool) {
+	return o.s.Int(dotPath)
+}
+
+func (o *ObservableJSONStruct) IntWithDefault(dotPath string, defaultValue int) int {
+	return o.s.IntWithDefault(dotPath, defaultValue)
+}
+
+func (o *ObservableJSONStruct) Duration(dotPath string) (time.Duration, error) {
+	return o.s.Duration(dotPath)
+}
+
+func (o *ObservableJSONStruct) DurationWithDefault(dotPath string, defaultValue time.Duration) (time.Duration, error) {
+	return o.s.DurationWithDefault(dotPath, defaultValue)
+}
+
+func (o *ObservableJSONStruct) List(dotPath string) ([]interface{}, bool) {
+	return o.s.List(dotPath)
+}
+
+func (o *ObservableJSONStruct) FindElement(dotPath string) (interface{}, bool) {
+	return o.s.FindElement(dotPath)
+}
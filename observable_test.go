@@ -0,0 +1,255 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObservableJSONStruct", func() {
+	type change struct {
+		path     string
+		oldValue interface{}
+		newValue interface{}
+	}
+
+	var (
+		values  *jsonstruct.ObservableJSONStruct
+		changes []change
+		record  jsonstruct.ChangeFunc
+	)
+
+	BeforeEach(func() {
+		doc := jsonstruct.New()
+		Expect(doc.SetInt(".server.port", 8080)).To(Succeed())
+		Expect(doc.SetString(".server.host", "localhost")).To(Succeed())
+		values = jsonstruct.NewObservable(doc)
+
+		changes = nil
+		record = func(path string, oldValue, newValue interface{}) {
+			changes = append(changes, change{path: path, oldValue: oldValue, newValue: newValue})
+		}
+	})
+
+	It("notifies exact path observers", func() {
+		Expect(values.OnChange(".server.port", record)).To(Succeed())
+
+		Expect(values.SetInt(".server.port", 9090)).To(Succeed())
+		Expect(values.SetString(".server.host", "example.com")).To(Succeed())
+
+		Expect(changes).To(Equal([]change{{path: ".server.port", oldValue: 8080, newValue: 9090}}))
+		Expect(values.IntWithDefault(".server.port", 0)).To(Equal(9090))
+	})
+
+	It("notifies every setter with nil for missing values", func() {
+		Expect(values.OnChange(".**", record)).To(Succeed())
+
+		Expect(values.SetDuration(".timeout", 3*time.Second)).To(Succeed())
+		Expect(values.SetList(".tags", []interface{}{"a"})).To(Succeed())
+		Expect(values.Delete(".server.host")).To(BeTrue())
+		Expect(values.Delete(".missing")).To(BeFalse())
+
+		Expect(changes).To(Equal([]change{
+			{path: ".timeout", oldValue: nil, newValue: "3s"},
+			{path: ".tags", oldValue: nil, newValue: []interface{}{"a"}},
+			{path: ".server.host", oldValue: "localhost", newValue: nil},
+		}))
+	})
+
+	It("matches wildcard patterns", func() {
+		Expect(values.OnChange(".*.port", record)).To(Succeed())
+
+		Expect(values.SetInt(".server.port", 9090)).To(Succeed())
+		Expect(values.SetInt(".admin.port", 9091)).To(Succeed())
+		Expect(values.SetInt(".admin.tls.port", 9443)).To(Succeed())
+
+		Expect(changes).To(Equal([]change{
+			{path: ".server.port", oldValue: 8080, newValue: 9090},
+			{path: ".admin.port", oldValue: nil, newValue: 9091},
+		}))
+	})
+
+	It("matches any number of keys with **", func() {
+		Expect(values.OnChange(".server.**", record)).To(Succeed())
+
+		Expect(values.SetInt(".server.tls.port", 9443)).To(Succeed())
+		Expect(values.SetInt(".admin.port", 9091)).To(Succeed())
+
+		Expect(changes).To(Equal([]change{{path: ".server.tls.port", oldValue: nil, newValue: 9443}}))
+	})
+
+	It("skips values that did not change", func() {
+		Expect(values.OnChange(".server.port", record)).To(Succeed())
+
+		Expect(values.SetInt(".server.port", 8080)).To(Succeed())
+
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("does not notify failed sets", func() {
+		Expect(values.OnChange(".**", record)).To(Succeed())
+
+		err := values.SetString(".server.port.number", "1")
+		Expect(errors.Is(err, jsonstruct.ErrNotAnObject)).To(BeTrue())
+
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("rejects invalid patterns", func() {
+		Expect(values.OnChange("server", record)).To(MatchError(jsonstruct.ErrUnsupportedPath))
+	})
+
+	Describe("Batch", func() {
+		It("notifies once with the common parent", func() {
+			Expect(values.OnChange(".server.*", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetInt(".server.port", 9090)).To(Succeed())
+				Expect(values.SetString(".server.host", "example.com")).To(Succeed())
+				Expect(changes).To(BeEmpty())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(Equal([]change{{
+				path:     ".server",
+				oldValue: map[string]interface{}{"port": 8080, "host": "localhost"},
+				newValue: map[string]interface{}{"port": 9090, "host": "example.com"},
+			}}))
+		})
+
+		It("reports the first old value when one path changes repeatedly", func() {
+			Expect(values.OnChange(".server.port", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetInt(".server.port", 9090)).To(Succeed())
+				Expect(values.SetInt(".server.port", 9091)).To(Succeed())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(Equal([]change{{path: ".server.port", oldValue: 8080, newValue: 9091}}))
+		})
+
+		It("restores added and deleted values in the old value", func() {
+			Expect(values.OnChange(".**", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetInt(".admin.port", 9091)).To(Succeed())
+				Expect(values.Delete(".server")).To(BeTrue())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(Equal([]change{{
+				path: ".",
+				oldValue: map[string]interface{}{
+					"server": map[string]interface{}{"port": 8080, "host": "localhost"},
+				},
+				newValue: map[string]interface{}{
+					"admin": map[string]interface{}{"port": 9091},
+				},
+			}}))
+		})
+
+		It("reports nil for a parent created in the batch", func() {
+			Expect(values.OnChange(".admin.*", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetInt(".admin.port", 9091)).To(Succeed())
+				Expect(values.SetString(".admin.host", "localhost")).To(Succeed())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(Equal([]change{{
+				path:     ".admin",
+				oldValue: nil,
+				newValue: map[string]interface{}{"port": 9091, "host": "localhost"},
+			}}))
+		})
+
+		It("restores values replaced by forced sets", func() {
+			doc := jsonstruct.New()
+			Expect(doc.SetInt(".a", 1)).To(Succeed())
+			values = jsonstruct.NewObservable(doc)
+			Expect(values.OnChange(".**", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetString(".a.b", "x", jsonstruct.Force)).To(Succeed())
+				Expect(values.SetString(".c", "y")).To(Succeed())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(Equal([]change{{
+				path:     ".",
+				oldValue: map[string]interface{}{"a": 1},
+				newValue: map[string]interface{}{"a": map[string]interface{}{"b": "x"}, "c": "y"},
+			}}))
+		})
+
+		It("reports the value of a parent that was forced into an object", func() {
+			doc := jsonstruct.New()
+			Expect(doc.SetInt(".a", 1)).To(Succeed())
+			values = jsonstruct.NewObservable(doc)
+			Expect(values.OnChange(".a.*", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetString(".a.b", "x", jsonstruct.Force)).To(Succeed())
+				Expect(values.SetString(".a.c", "y")).To(Succeed())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(Equal([]change{{
+				path:     ".a",
+				oldValue: 1,
+				newValue: map[string]interface{}{"b": "x", "c": "y"},
+			}}))
+		})
+
+		It("skips batches that end where they started", func() {
+			Expect(values.OnChange(".server.*", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetInt(".server.port", 9090)).To(Succeed())
+				Expect(values.SetInt(".server.port", 8080)).To(Succeed())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(BeEmpty())
+		})
+
+		It("notifies when the outermost batch finishes", func() {
+			Expect(values.OnChange(".server.port", record)).To(Succeed())
+
+			Expect(values.Batch(func() error {
+				Expect(values.Batch(func() error {
+					return values.SetInt(".server.port", 9090)
+				})).To(Succeed())
+				Expect(changes).To(BeEmpty())
+				return nil
+			})).To(Succeed())
+
+			Expect(changes).To(HaveLen(1))
+		})
+
+		It("returns the error and still notifies applied changes", func() {
+			Expect(values.OnChange(".server.port", record)).To(Succeed())
+			failure := errors.New("failure")
+
+			Expect(values.Batch(func() error {
+				Expect(values.SetInt(".server.port", 9090)).To(Succeed())
+				return failure
+			})).To(MatchError(failure))
+
+			Expect(changes).To(Equal([]change{{path: ".server.port", oldValue: 8080, newValue: 9090}}))
+		})
+	})
+
+	It("marshals the document", func() {
+		data, err := json.Marshal(values)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"server":{"port":8080,"host":"localhost"}}`))
+	})
+})
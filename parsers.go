@@ -0,0 +1,141 @@
+package jsonstruct
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+type HostPort struct {
+	Host string
+	Port int
+}
+
+func (h HostPort) String() string {
+	return net.JoinHostPort(h.Host, strconv.Itoa(h.Port))
+}
+
+func (s JSONStruct) URL(dotPath string) (*url.URL, error) {
+	return parsedString(s, dotPath, parseURL)
+}
+
+func (s JSONStruct) URLList(dotPath string) ([]*url.URL, error) {
+	return parsedStringList(s, dotPath, parseURL)
+}
+
+func (s JSONStruct) Addr(dotPath string) (netip.Addr, error) {
+	return parsedString(s, dotPath, netip.ParseAddr)
+}
+
+func (s JSONStruct) AddrList(dotPath string) ([]netip.Addr, error) {
+	return parsedStringList(s, dotPath, netip.ParseAddr)
+}
+
+// IP is like Addr but returns a net.IP for use with the net package.
+func (s JSONStruct) IP(dotPath string) (net.IP, error) {
+	return parsedString(s, dotPath, parseIP)
+}
+
+func (s JSONStruct) IPList(dotPath string) ([]net.IP, error) {
+	return parsedStringList(s, dotPath, parseIP)
+}
+
+func (s JSONStruct) Prefix(dotPath string) (netip.Prefix, error) {
+	return parsedString(s, dotPath, netip.ParsePrefix)
+}
+
+func (s JSONStruct) PrefixList(dotPath string) ([]netip.Prefix, error) {
+	return parsedStringList(s, dotPath, netip.ParsePrefix)
+}
+
+func (s JSONStruct) HostPort(dotPath string) (HostPort, error) {
+	return parsedString(s, dotPath, parseHostPort)
+}
+
+func (s JSONStruct) HostPortList(dotPath string) ([]HostPort, error) {
+	return parsedStringList(s, dotPath, parseHostPort)
+}
+
+func (s JSONStruct) Regexp(dotPath string) (*regexp.Regexp, error) {
+	return parsedString(s, dotPath, regexp.Compile)
+}
+
+func (s JSONStruct) RegexpList(dotPath string) ([]*regexp.Regexp, error) {
+	return parsedStringList(s, dotPath, regexp.Compile)
+}
+
+func parsedString[T any](s JSONStruct, dotPath string, parse func(string) (T, error)) (T, error) {
+	var zero T
+
+	value, ok := s.FindElement(dotPath)
+	if !ok {
+		return zero, ErrValueNotFound
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return zero, conversionError(dotPath, value, reflect.TypeOf(zero))
+	}
+
+	parsed, err := parse(str)
+	if err != nil {
+		return zero, &PathError{Path: dotPath, Err: err}
+	}
+
+	return parsed, nil
+}
+
+func parsedStringList[T any](s JSONStruct, dotPath string, parse func(string) (T, error)) ([]T, error) {
+	return typedList(s, dotPath, func(value interface{}) (T, error) {
+		str, ok := value.(string)
+		if !ok {
+			var zero T
+			return zero, errWrongType
+		}
+		return parse(str)
+	})
+}
+
+func parseURL(value string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("URL %q has no scheme", value)
+	}
+	return u, nil
+}
+
+func parseIP(value string) (net.IP, error) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return nil, err
+	}
+	if addr.Zone() != "" {
+		return nil, fmt.Errorf("IP address %q has a zone", value)
+	}
+	return net.IP(addr.AsSlice()), nil
+}
+
+func parseHostPort(value string) (HostPort, error) {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return HostPort{}, err
+	}
+	if host == "" {
+		return HostPort{}, fmt.Errorf("Address %q has no host", value)
+	}
+
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return HostPort{}, errors.New("Invalid port " + strconv.Quote(port))
+	}
+
+	return HostPort{Host: host, Port: int(portNumber)}, nil
+}
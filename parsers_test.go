@@ -0,0 +1,130 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parsed strings", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(json.Unmarshal([]byte(`{
+			"url": "https://example.com:8443/path?q=1",
+			"urls": ["http://a.example", "http://b.example"],
+			"relative": "/just/a/path",
+			"ipv4": "10.0.0.1",
+			"ipv6": "::1",
+			"zoned": "fe80::1%eth0",
+			"ips": ["10.0.0.1", "10.0.0.2"],
+			"cidr": "10.0.0.0/8",
+			"cidrs": ["10.0.0.0/8", "bad"],
+			"listen": "localhost:8080",
+			"listenV6": "[::1]:443",
+			"listens": ["a:1", "b:2"],
+			"noPort": "localhost",
+			"badPort": "localhost:99999",
+			"regexp": "^a+$",
+			"badRegexp": "(",
+			"number": 12
+		}`), &values)).To(Succeed())
+	})
+
+	It("parses URLs", func() {
+		u, err := values.URL(".url")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Hostname()).To(Equal("example.com"))
+		Expect(u.Port()).To(Equal("8443"))
+
+		urls, err := values.URLList(".urls")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(urls).To(HaveLen(2))
+		Expect(urls[1].Host).To(Equal("b.example"))
+
+		_, err = values.URL(".relative")
+		Expect(err).To(MatchError(`.relative: URL "/just/a/path" has no scheme`))
+	})
+
+	It("parses addresses", func() {
+		Expect(values.Addr(".ipv4")).To(Equal(netip.MustParseAddr("10.0.0.1")))
+		Expect(values.Addr(".ipv6")).To(Equal(netip.MustParseAddr("::1")))
+		Expect(values.AddrList(".ips")).To(Equal([]netip.Addr{
+			netip.MustParseAddr("10.0.0.1"),
+			netip.MustParseAddr("10.0.0.2"),
+		}))
+
+		ip, err := values.IP(".ipv4")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ip.Equal(net.ParseIP("10.0.0.1"))).To(BeTrue())
+
+		ips, err := values.IPList(".ips")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ips).To(HaveLen(2))
+
+		_, err = values.Addr(".url")
+		Expect(err).To(HaveOccurred())
+		_, err = values.IP(".zoned")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("parses prefixes", func() {
+		Expect(values.Prefix(".cidr")).To(Equal(netip.MustParsePrefix("10.0.0.0/8")))
+
+		_, err := values.PrefixList(".cidrs")
+		Expect(err).To(MatchError(HavePrefix(".cidrs[1]: ")))
+	})
+
+	It("parses host and port pairs", func() {
+		Expect(values.HostPort(".listen")).To(Equal(jsonstruct.HostPort{Host: "localhost", Port: 8080}))
+
+		hostPort, err := values.HostPort(".listenV6")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostPort.Host).To(Equal("::1"))
+		Expect(hostPort.String()).To(Equal("[::1]:443"))
+
+		Expect(values.HostPortList(".listens")).To(Equal([]jsonstruct.HostPort{{Host: "a", Port: 1}, {Host: "b", Port: 2}}))
+
+		_, err = values.HostPort(".noPort")
+		Expect(err).To(HaveOccurred())
+		_, err = values.HostPort(".badPort")
+		Expect(err).To(MatchError(`.badPort: Invalid port "99999"`))
+	})
+
+	It("compiles regular expressions", func() {
+		re, err := values.Regexp(".regexp")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(re.MatchString("aaa")).To(BeTrue())
+
+		regexps, err := values.RegexpList(".urls")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(regexps).To(HaveLen(2))
+
+		_, err = values.Regexp(".badRegexp")
+		Expect(err).To(MatchError(HavePrefix(".badRegexp: ")))
+	})
+
+	It("returns a not found error when the value doesn't exist", func() {
+		_, err := values.URL(".missing")
+		Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		_, err = values.AddrList(".missing")
+		Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+	})
+
+	It("returns a path error for values that aren't strings", func() {
+		_, err := values.Prefix(".number")
+		Expect(err).To(MatchError(".number: Cannot convert number to netip.Prefix"))
+
+		values["mixed"] = []interface{}{"10.0.0.1", 2}
+		_, err = values.AddrList(".mixed")
+		Expect(err).To(MatchError(".mixed[1]: Cannot convert number to netip.Addr"))
+	})
+})
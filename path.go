@@ -0,0 +1,152 @@
+package jsonstruct
+
+import (
+	"time"
+)
+
+// Path is a dot path that has been parsed once so it can be reused without
+// re-parsing on every access.
+type Path struct {
+	dotPath string
+	keys    []string
+}
+
+func CompilePath(dotPath string) (Path, error) {
+	keys, err := splitPath(dotPath)
+	if err != nil {
+		return Path{}, err
+	}
+
+	return Path{dotPath: dotPath, keys: keys}, nil
+}
+
+func MustCompilePath(dotPath string) Path {
+	path, err := CompilePath(dotPath)
+	if err != nil {
+		panic(&PathError{Path: dotPath, Err: err})
+	}
+
+	return path
+}
+
+func (p Path) String() string {
+	return p.dotPath
+}
+
+func (s JSONStruct) StringAt(path Path) (string, bool) {
+	value, ok := s.FindElementAt(path)
+	if !ok {
+		return "", false
+	}
+
+	return toString(value)
+}
+
+func (s JSONStruct) StringWithDefaultAt(path Path, defaultValue string) string {
+	value, ok := s.StringAt(path)
+	if !ok {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s JSONStruct) IntAt(path Path) (int, bool) {
+	value, ok := s.FindElementAt(path)
+	if !ok {
+		return 0, false
+	}
+
+	return toInt(value)
+}
+
+func (s JSONStruct) IntWithDefaultAt(path Path, defaultValue int) int {
+	value, ok := s.IntAt(path)
+	if !ok {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s JSONStruct) DurationAt(path Path) (time.Duration, error) {
+	value, ok := s.FindElementAt(path)
+	if !ok {
+		return 0, ErrValueNotFound
+	}
+
+	duration, err := toDuration(value, time.Second)
+	if err == errWrongType {
+		return 0, conversionError(path.dotPath, value, durationType)
+	}
+
+	return duration, err
+}
+
+func (s JSONStruct) DurationWithDefaultAt(path Path, defaultValue time.Duration) (time.Duration, error) {
+	value, err := s.DurationAt(path)
+	return durationWithDefault(value, err, defaultValue)
+}
+
+func (s JSONStruct) ListAt(path Path) ([]interface{}, bool) {
+	value, ok := s.FindElementAt(path)
+	if !ok {
+		return nil, false
+	}
+
+	list, ok := value.([]interface{})
+	return list, ok
+}
+
+func (s JSONStruct) FindElementAt(path Path) (interface{}, bool) {
+	if path.keys == nil {
+		return nil, false
+	}
+
+	return s.findElement(path.keys)
+}
+
+func (s JSONStruct) SetStringAt(path Path, value string, opts ...SetOption) error {
+	parent, lastKey, err := s.findParentAt(path, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = value
+	return nil
+}
+
+func (s JSONStruct) SetIntAt(path Path, value int, opts ...SetOption) error {
+	parent, lastKey, err := s.findParentAt(path, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = value
+	return nil
+}
+
+func (s JSONStruct) SetDurationAt(path Path, value time.Duration, opts ...SetOption) error {
+	parent, lastKey, err := s.findParentAt(path, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = value.String()
+	return nil
+}
+
+func (s JSONStruct) SetListAt(path Path, value []interface{}, opts ...SetOption) error {
+	parent, lastKey, err := s.findParentAt(path, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = value
+	return nil
+}
+
+func (s JSONStruct) findParentAt(path Path, opts []SetOption) (JSONStruct, string, error) {
+	if path.keys == nil {
+		// The zero Path was never compiled
+		return nil, "", ErrUnsupportedPath
+	}
+
+	return s.findParentKeys(path.keys, opts)
+}
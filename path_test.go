@@ -0,0 +1,117 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Path", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(json.Unmarshal([]byte(`{
+			"parent": {
+				"string": "value",
+				"int": 98765,
+				"duration": "20s",
+				"list": [1, 2]
+			}
+		}`), &values)).To(Succeed())
+	})
+
+	Describe("CompilePath()", func() {
+		It("returns an error for unsupported paths", func() {
+			_, err := jsonstruct.CompilePath("parent")
+			Expect(err).To(Equal(jsonstruct.ErrUnsupportedPath))
+
+			_, err = jsonstruct.CompilePath("")
+			Expect(err).To(Equal(jsonstruct.ErrUnsupportedPath))
+		})
+
+		It("remembers the dot path it was compiled from", func() {
+			path, err := jsonstruct.CompilePath(".parent.string")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path.String()).To(Equal(".parent.string"))
+		})
+	})
+
+	Describe("MustCompilePath()", func() {
+		It("panics for unsupported paths", func() {
+			Expect(func() { jsonstruct.MustCompilePath("parent") }).To(Panic())
+		})
+	})
+
+	Describe("getters", func() {
+		It("return the same values as the dot path getters", func() {
+			str, ok := values.StringAt(jsonstruct.MustCompilePath(".parent.string"))
+			Expect(ok).To(BeTrue())
+			Expect(str).To(Equal("value"))
+			Expect(values.StringWithDefaultAt(jsonstruct.MustCompilePath(".parent.missing"), "default")).To(Equal("default"))
+			i, ok := values.IntAt(jsonstruct.MustCompilePath(".parent.int"))
+			Expect(ok).To(BeTrue())
+			Expect(i).To(Equal(98765))
+			Expect(values.IntWithDefaultAt(jsonstruct.MustCompilePath(".parent.missing"), 42)).To(Equal(42))
+			Expect(values.DurationAt(jsonstruct.MustCompilePath(".parent.duration"))).To(Equal(20 * time.Second))
+			Expect(values.DurationWithDefaultAt(jsonstruct.MustCompilePath(".parent.missing"), time.Second)).To(Equal(time.Second))
+			list, ok := values.ListAt(jsonstruct.MustCompilePath(".parent.list"))
+			Expect(ok).To(BeTrue())
+			Expect(list).To(Equal([]interface{}{1.0, 2.0}))
+		})
+
+		It("return not found for missing values", func() {
+			_, ok := values.StringAt(jsonstruct.MustCompilePath(".parent.missing"))
+			Expect(ok).To(BeFalse())
+
+			_, err := values.DurationAt(jsonstruct.MustCompilePath(".parent.missing"))
+			Expect(err).To(Equal(jsonstruct.ErrValueNotFound))
+		})
+
+		It("return not found for the zero Path", func() {
+			_, ok := values.FindElementAt(jsonstruct.Path{})
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("setters", func() {
+		It("set values that can be read back", func() {
+			values = jsonstruct.New()
+
+			Expect(values.SetStringAt(jsonstruct.MustCompilePath(".one.two.string"), "hi")).To(Succeed())
+			Expect(values.SetIntAt(jsonstruct.MustCompilePath(".one.int"), 12)).To(Succeed())
+			Expect(values.SetDurationAt(jsonstruct.MustCompilePath(".one.duration"), 32*time.Second)).To(Succeed())
+			Expect(values.SetListAt(jsonstruct.MustCompilePath(".list"), []interface{}{"a"})).To(Succeed())
+
+			data, err := json.Marshal(values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{
+				"one": {
+					"two": { "string": "hi" },
+					"int": 12,
+					"duration": "32s"
+				},
+				"list": ["a"]
+			}`))
+		})
+
+		It("can reuse a path for many documents", func() {
+			path := jsonstruct.MustCompilePath(".a.b")
+			for i := 0; i < 3; i++ {
+				values = jsonstruct.New()
+				Expect(values.SetIntAt(path, i)).To(Succeed())
+				Expect(values.IntWithDefaultAt(path, -1)).To(Equal(i))
+			}
+		})
+
+		It("return an error for the zero Path", func() {
+			Expect(values.SetStringAt(jsonstruct.Path{}, "x")).To(Equal(jsonstruct.ErrUnsupportedPath))
+		})
+	})
+})
@@ -0,0 +1,151 @@
+package jsonstruct
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// DefaultSensitiveKeys are matched against key names, ignoring case, "-" and
+// "_", when RedactOptions.SensitiveKeys is nil. A key is sensitive if it
+// contains any of them.
+var DefaultSensitiveKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"privatekey",
+	"credential",
+}
+
+const DefaultMask = "[REDACTED]"
+
+type RedactOptions struct {
+	// Paths are dot path patterns of values to mask. "*" matches any single
+	// key and an empty key matches any number of keys, so "..password"
+	// matches a password key at any depth. A pattern that does not start
+	// with "." is treated as a key name at any depth.
+	Paths []string
+	// SensitiveKeys replaces DefaultSensitiveKeys when not nil. Use an empty
+	// slice to mask by Paths only.
+	SensitiveKeys []string
+	// Mask replaces masked values, DefaultMask if empty
+	Mask string
+}
+
+// Redacted returns a deep copy with every matching value, including whole
+// objects and lists, replaced by the mask. Go maps, slices and structs set
+// directly into the document are converted to JSON values first. Keys inside lists of objects are
+// matched as if the list were not there.
+func (s JSONStruct) Redacted(opts RedactOptions) JSONStruct {
+	r := redactor{mask: opts.Mask, sensitiveKeys: opts.SensitiveKeys}
+	if r.mask == "" {
+		r.mask = DefaultMask
+	}
+	if r.sensitiveKeys == nil {
+		r.sensitiveKeys = DefaultSensitiveKeys
+	}
+	for _, pattern := range opts.Paths {
+		r.patterns = append(r.patterns, redactPattern(pattern))
+	}
+
+	return JSONStruct(r.object(nil, s))
+}
+
+// LogValue logs the document with the default RedactOptions applied. Objects
+// are logged as groups.
+func (s JSONStruct) LogValue() slog.Value {
+	return logValue(map[string]interface{}(s.Redacted(RedactOptions{})))
+}
+
+type redactor struct {
+	patterns      [][]string
+	sensitiveKeys []string
+	mask          string
+}
+
+func redactPattern(pattern string) []string {
+	if !strings.HasPrefix(pattern, ".") {
+		return []string{"**", pattern}
+	}
+
+	keys, _ := splitPath(pattern)
+	for i, key := range keys {
+		if key == "" {
+			keys[i] = "**"
+		}
+	}
+	return keys
+}
+
+func (r redactor) object(keys []string, object map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		childKeys := append(keys[:len(keys):len(keys)], key)
+		if r.sensitive(childKeys) {
+			result[key] = r.mask
+		} else {
+			result[key] = r.value(childKeys, value)
+		}
+	}
+	return result
+}
+
+func (r redactor) value(keys []string, value interface{}) interface{} {
+	// Typed Go maps and slices set directly into the document must be
+	// searched for secrets and copied too
+	switch value := asObject(canonicalValue(keysToPath(keys), value)).(type) {
+	case map[string]interface{}:
+		return r.object(keys, value)
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, element := range value {
+			list[i] = r.value(keys, element)
+		}
+		return list
+	default:
+		return value
+	}
+}
+
+func (r redactor) sensitive(keys []string) bool {
+	for _, pattern := range r.patterns {
+		if matchPattern(pattern, keys) {
+			return true
+		}
+	}
+
+	name := normalizeKeyName(keys[len(keys)-1])
+	for _, sensitiveKey := range r.sensitiveKeys {
+		if strings.Contains(name, normalizeKeyName(sensitiveKey)) {
+			return true
+		}
+	}
+	return false
+}
+
+var keyNameReplacer = strings.NewReplacer("-", "", "_", "")
+
+func normalizeKeyName(name string) string {
+	return keyNameReplacer.Replace(strings.ToLower(name))
+}
+
+func logValue(value interface{}) slog.Value {
+	object, ok := asObject(value).(map[string]interface{})
+	if !ok {
+		return slog.AnyValue(value)
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, len(keys))
+	for i, key := range keys {
+		attrs[i] = slog.Attr{Key: key, Value: logValue(object[key])}
+	}
+	return slog.GroupValue(attrs...)
+}
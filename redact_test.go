@@ -0,0 +1,125 @@
+package jsonstruct_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redacted", func() {
+	var (
+		values jsonstruct.JSONStruct
+	)
+
+	BeforeEach(func() {
+		err := json.Unmarshal([]byte(`{
+			"database": {"host": "db", "password": "hunter2", "DB_Password": "hunter3"},
+			"github": {"token": "ghp", "user": "octocat"},
+			"clients": [{"name": "a", "api-key": "k1"}, {"name": "b", "api-key": "k2"}],
+			"tls": {"cert": "cert.pem", "key": "key.pem"},
+			"auth": {"secrets": {"a": "x", "b": "y"}}
+		}`), &values)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("masks sensitive key names by default", func() {
+		redacted := values.Redacted(jsonstruct.RedactOptions{})
+
+		data, err := json.Marshal(redacted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"database": {"host": "db", "password": "[REDACTED]", "DB_Password": "[REDACTED]"},
+			"github": {"token": "[REDACTED]", "user": "octocat"},
+			"clients": [{"name": "a", "api-key": "[REDACTED]"}, {"name": "b", "api-key": "[REDACTED]"}],
+			"tls": {"cert": "cert.pem", "key": "key.pem"},
+			"auth": {"secrets": "[REDACTED]"}
+		}`))
+	})
+
+	It("does not modify the original", func() {
+		values.Redacted(jsonstruct.RedactOptions{})
+
+		Expect(values.StringWithDefault(".database.password", "")).To(Equal("hunter2"))
+		list, _ := values.List(".clients")
+		Expect(list[0]).To(HaveKeyWithValue("api-key", "k1"))
+	})
+
+	It("masks and copies Go maps and slices set directly", func() {
+		hosts := []string{"a", "b"}
+		values := jsonstruct.JSONStruct{
+			"db":      map[string]string{"host": "db", "password": "hunter2"},
+			"clients": []map[string]string{{"name": "a", "api-key": "k1"}},
+			"tokens":  []string{"t1", "t2"},
+			"hosts":   hosts,
+		}
+
+		redacted := values.Redacted(jsonstruct.RedactOptions{})
+		hosts[0] = "changed"
+
+		data, err := json.Marshal(redacted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"db": {"host": "db", "password": "[REDACTED]"},
+			"clients": [{"name": "a", "api-key": "[REDACTED]"}],
+			"tokens": "[REDACTED]",
+			"hosts": ["a", "b"]
+		}`))
+	})
+
+	It("masks matching path patterns", func() {
+		redacted := values.Redacted(jsonstruct.RedactOptions{
+			Paths:         []string{".tls.key", ".*.user", "..name"},
+			SensitiveKeys: []string{},
+			Mask:          "***",
+		})
+
+		Expect(redacted.StringWithDefault(".tls.key", "")).To(Equal("***"))
+		Expect(redacted.StringWithDefault(".tls.cert", "")).To(Equal("cert.pem"))
+		Expect(redacted.StringWithDefault(".github.user", "")).To(Equal("***"))
+		Expect(redacted.StringWithDefault(".database.password", "")).To(Equal("hunter2"))
+		list, _ := redacted.List(".clients")
+		Expect(list[1]).To(Equal(map[string]interface{}{"name": "***", "api-key": "k2"}))
+	})
+
+	It("treats patterns without a leading dot as key names", func() {
+		redacted := values.Redacted(jsonstruct.RedactOptions{Paths: []string{"host"}})
+
+		Expect(redacted.StringWithDefault(".database.host", "")).To(Equal("[REDACTED]"))
+	})
+
+	It("matches custom sensitive keys ignoring case and separators", func() {
+		redacted := values.Redacted(jsonstruct.RedactOptions{SensitiveKeys: []string{"USER"}})
+
+		Expect(redacted.StringWithDefault(".github.user", "")).To(Equal("[REDACTED]"))
+		Expect(redacted.StringWithDefault(".github.token", "")).To(Equal("ghp"))
+	})
+
+	Describe("LogValue", func() {
+		It("logs the redacted document as groups", func() {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && a.Key != "config" {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+
+			logger.Info("starting", "config", values)
+
+			Expect(buf.Bytes()).To(MatchJSON(`{"config": {
+				"auth": {"secrets": "[REDACTED]"},
+				"clients": [{"name": "a", "api-key": "[REDACTED]"}, {"name": "b", "api-key": "[REDACTED]"}],
+				"database": {"DB_Password": "[REDACTED]", "host": "db", "password": "[REDACTED]"},
+				"github": {"token": "[REDACTED]", "user": "octocat"},
+				"tls": {"cert": "cert.pem", "key": "key.pem"}
+			}}`))
+		})
+	})
+})
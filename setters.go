@@ -6,8 +6,22 @@ import (
 	"time"
 )
 
-func (s JSONStruct) SetString(dotPath, value string) error {
-	parent, lastKey, err := s.findParent(dotPath)
+type SetOption int
+
+const (
+	// Force makes a setter replace any intermediate value on the path that
+	// is not an object with a new, empty object. Without it the setter
+	// returns a *PathError wrapping ErrNotAnObject and leaves the document
+	// untouched.
+	Force SetOption = iota + 1
+)
+
+var (
+	ErrNotAnObject = errors.New("Value is not an object")
+)
+
+func (s JSONStruct) SetString(dotPath, value string, opts ...SetOption) error {
+	parent, lastKey, err := s.findParent(dotPath, opts)
 	if err != nil {
 		return err
 	}
@@ -15,8 +29,8 @@ func (s JSONStruct) SetString(dotPath, value string) error {
 	return nil
 }
 
-func (s JSONStruct) SetInt(dotPath string, value int) error {
-	parent, lastKey, err := s.findParent(dotPath)
+func (s JSONStruct) SetInt(dotPath string, value int, opts ...SetOption) error {
+	parent, lastKey, err := s.findParent(dotPath, opts)
 	if err != nil {
 		return err
 	}
@@ -24,8 +38,8 @@ func (s JSONStruct) SetInt(dotPath string, value int) error {
 	return nil
 }
 
-func (s JSONStruct) SetDuration(dotPath string, value time.Duration) error {
-	parent, lastKey, err := s.findParent(dotPath)
+func (s JSONStruct) SetDuration(dotPath string, value time.Duration, opts ...SetOption) error {
+	parent, lastKey, err := s.findParent(dotPath, opts)
 	if err != nil {
 		return err
 	}
@@ -33,8 +47,8 @@ func (s JSONStruct) SetDuration(dotPath string, value time.Duration) error {
 	return nil
 }
 
-func (s JSONStruct) SetList(dotPath string, value []interface{}) error {
-	parent, lastKey, err := s.findParent(dotPath)
+func (s JSONStruct) SetList(dotPath string, value []interface{}, opts ...SetOption) error {
+	parent, lastKey, err := s.findParent(dotPath, opts)
 	if err != nil {
 		return err
 	}
@@ -42,33 +56,95 @@ func (s JSONStruct) SetList(dotPath string, value []interface{}) error {
 	return nil
 }
 
-func (s JSONStruct) findParent(dotPath string) (JSONStruct, string, error) {
-	if dotPath[0:1] != "." {
-		return nil, "", errors.New("Only . paths are currently supported")
+// SetValue stores value exactly as given, e.g. keeping json.Number values
+// that Set would convert. value should be one of the types the JSON decoder
+// produces so the getters can read it back.
+func (s JSONStruct) SetValue(dotPath string, value interface{}, opts ...SetOption) error {
+	parent, lastKey, err := s.findParent(dotPath, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = value
+	return nil
+}
+
+// Delete removes the value at dotPath and reports whether it was present.
+func (s JSONStruct) Delete(dotPath string) bool {
+	keys, err := splitPath(dotPath)
+	if err != nil {
+		return false
+	}
+
+	return s.deleteKeys(keys)
+}
+
+func (s JSONStruct) deleteKeys(keys []string) bool {
+	parent := map[string]interface{}(s)
+	if len(keys) > 1 {
+		value, ok := s.findElement(keys[:len(keys)-1])
+		if !ok {
+			return false
+		}
+		parent, ok = asObject(value).(map[string]interface{})
+		if !ok {
+			return false
+		}
 	}
 
-	keys := strings.Split(dotPath[1:], ".")
-	if len(keys) == 1 {
-		return s, keys[0], nil
+	lastKey := keys[len(keys)-1]
+	_, ok := parent[lastKey]
+	delete(parent, lastKey)
+	return ok
+}
+
+func (s JSONStruct) findParent(dotPath string, opts []SetOption) (JSONStruct, string, error) {
+	keys, err := splitPath(dotPath)
+	if err != nil {
+		return nil, "", err
 	}
+
+	return s.findParentKeys(keys, opts)
+}
+
+func (s JSONStruct) findParentKeys(keys []string, opts []SetOption) (JSONStruct, string, error) {
 	lastKey := keys[len(keys)-1]
-	keys = keys[0 : len(keys)-1]
+
+	// Check the whole path before creating anything so a conflict leaves
+	// the document unchanged
+	if !hasOption(opts, Force) {
+		parent := s
+		for i, key := range keys[:len(keys)-1] {
+			child, ok := asObject(parent[key]).(map[string]interface{})
+			if ok {
+				parent = child
+				continue
+			}
+			if parent[key] != nil {
+				return nil, "", &PathError{Path: "." + strings.Join(keys[:i+1], "."), Err: ErrNotAnObject}
+			}
+			break
+		}
+	}
 
 	value := s
-	for _, key := range keys {
-		var ok bool
-		child, ok := value[key]
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := asObject(value[key]).(map[string]interface{})
 		if !ok {
+			// Missing, null or (when forced) any other value
 			child = make(map[string]interface{})
 			value[key] = child
 		}
-
-		value, ok = child.(map[string]interface{})
-		if !ok {
-			newValue := make(map[string]interface{})
-			value[key] = newValue
-		}
+		value = child
 	}
 
 	return value, lastKey, nil
 }
+
+func hasOption(opts []SetOption, option SetOption) bool {
+	for _, opt := range opts {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
@@ -121,4 +121,102 @@ var _ = Describe("Setters", func() {
 			}`))
 		})
 	})
+
+	Describe("SetValue()", func() {
+		It("stores the value as given", func() {
+			values = jsonstruct.New()
+
+			Expect(values.SetValue(".doc.big", json.Number("12345678901234567891"))).To(Succeed())
+
+			value, ok := values.FindElement(".doc.big")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(json.Number("12345678901234567891")))
+		})
+
+		It("applies the set options", func() {
+			values = jsonstruct.New()
+			Expect(values.SetInt(".doc", 1)).To(Succeed())
+
+			Expect(values.SetValue(".doc.x", true)).To(MatchError(".doc: Value is not an object"))
+			Expect(values.SetValue(".doc.x", true, jsonstruct.Force)).To(Succeed())
+		})
+	})
+
+	Describe("Delete()", func() {
+		BeforeEach(func() {
+			values = jsonstruct.New()
+			Expect(values.SetString(".parent.child", "value")).To(Succeed())
+			Expect(values.SetString(".top", "value")).To(Succeed())
+		})
+
+		It("removes values and reports whether they were present", func() {
+			Expect(values.Delete(".parent.child")).To(BeTrue())
+			Expect(values.Delete(".top")).To(BeTrue())
+			Expect(values).To(Equal(jsonstruct.JSONStruct{"parent": map[string]interface{}{}}))
+
+			Expect(values.Delete(".parent.child")).To(BeFalse())
+		})
+
+		It("returns false for missing parents and unsupported paths", func() {
+			Expect(values.Delete(".missing.child")).To(BeFalse())
+			Expect(values.Delete(".top.child")).To(BeFalse())
+			Expect(values.Delete("top")).To(BeFalse())
+		})
+	})
+
+	Describe("intermediate values", func() {
+		BeforeEach(func() {
+			values = jsonstruct.New()
+			Expect(values.SetInt(".age", 30)).To(Succeed())
+			Expect(values.SetString(".parent.child", "value")).To(Succeed())
+		})
+
+		It("returns an error naming the conflicting segment instead of overwriting", func() {
+			err := values.SetString(".age.years", "thirty")
+			Expect(err).To(MatchError(".age: Value is not an object"))
+
+			pathErr, ok := err.(*jsonstruct.PathError)
+			Expect(ok).To(BeTrue())
+			Expect(pathErr.Err).To(Equal(jsonstruct.ErrNotAnObject))
+
+			Expect(values.IntWithDefault(".age", 0)).To(Equal(30))
+		})
+
+		It("reports conflicts deeper in the path without modifying the document", func() {
+			err := values.SetInt(".parent.child.grandchild.x", 1)
+			Expect(err).To(MatchError(".parent.child: Value is not an object"))
+
+			Expect(values.StringWithDefault(".parent.child", "")).To(Equal("value"))
+		})
+
+		It("replaces null intermediates", func() {
+			values["nothing"] = nil
+			Expect(values.SetString(".nothing.something", "x")).To(Succeed())
+			Expect(values.StringWithDefault(".nothing.something", "")).To(Equal("x"))
+		})
+
+		It("descends into nested JSONStructs", func() {
+			values["sub"] = jsonstruct.New()
+			Expect(values.SetString(".sub.x", "y")).To(Succeed())
+			Expect(values.StringWithDefault(".sub.x", "")).To(Equal("y"))
+			Expect(values.TypeOf(".sub.x")).To(Equal(jsonstruct.String))
+			Expect(jsonstruct.Get[string](values, ".sub.x")).To(Equal("y"))
+			Expect(values.Require(".sub.x")).To(Succeed())
+		})
+
+		It("overwrites intermediate values when forced", func() {
+			Expect(values.SetString(".age.years", "thirty", jsonstruct.Force)).To(Succeed())
+			Expect(values.StringWithDefault(".age.years", "")).To(Equal("thirty"))
+
+			Expect(values.SetList(".parent.child.list", []interface{}{1}, jsonstruct.Force)).To(Succeed())
+			Expect(values.SetDuration(".parent.child.d", time.Second, jsonstruct.Force)).To(Succeed())
+
+			data, err := json.Marshal(values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{
+				"age": { "years": "thirty" },
+				"parent": { "child": { "list": [1], "d": "1s" } }
+			}`))
+		})
+	})
 })
@@ -0,0 +1,130 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// SyncJSONStruct guards a JSONStruct with a read/write mutex so it can be
+// shared between goroutines. Lists and objects returned by the getters are
+// shared with the document and must not be modified; use Snapshot or Update
+// instead.
+type SyncJSONStruct struct {
+	mutex sync.RWMutex
+	s     JSONStruct
+}
+
+func NewSync(s JSONStruct) *SyncJSONStruct {
+	if s == nil {
+		s = New()
+	}
+	return &SyncJSONStruct{s: s}
+}
+
+func (s *SyncJSONStruct) Snapshot() JSONStruct {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.DeepCopy()
+}
+
+// Update calls fn with a copy of the document and replaces the document with
+// the copy only if fn succeeds, so either all of fn's edits are visible to
+// other goroutines or none are.
+func (s *SyncJSONStruct) Update(fn func(JSONStruct) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	copy := s.s.DeepCopy()
+	err := fn(copy)
+	if err != nil {
+		return err
+	}
+
+	s.s = copy
+	return nil
+}
+
+func (s *SyncJSONStruct) MarshalJSON() ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return json.Marshal(s.s)
+}
+
+func (s *SyncJSONStruct) String(dotPath string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.String(dotPath)
+}
+
+func (s *SyncJSONStruct) StringWithDefault(dotPath, defaultValue string) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.StringWithDefault(dotPath, defaultValue)
+}
+
+func (s *SyncJSONStruct) Int(dotPath string) (int, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.Int(dotPath)
+}
+
+func (s *SyncJSONStruct) IntWithDefault(dotPath string, defaultValue int) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.IntWithDefault(dotPath, defaultValue)
+}
+
+func (s *SyncJSONStruct) Duration(dotPath string) (time.Duration, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.Duration(dotPath)
+}
+
+func (s *SyncJSONStruct) DurationWithDefault(dotPath string, defaultValue time.Duration) (time.Duration, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.DurationWithDefault(dotPath, defaultValue)
+}
+
+func (s *SyncJSONStruct) List(dotPath string) ([]interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.List(dotPath)
+}
+
+func (s *SyncJSONStruct) FindElement(dotPath string) (interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.s.FindElement(dotPath)
+}
+
+func (s *SyncJSONStruct) SetString(dotPath, value string, opts ...SetOption) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.s.SetString(dotPath, value, opts...)
+}
+
+func (s *SyncJSONStruct) SetInt(dotPath string, value int, opts ...SetOption) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.s.SetInt(dotPath, value, opts...)
+}
+
+func (s *SyncJSONStruct) SetDuration(dotPath string, value time.Duration, opts ...SetOption) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.s.SetDuration(dotPath, value, opts...)
+}
+
+func (s *SyncJSONStruct) SetList(dotPath string, value []interface{}, opts ...SetOption) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.s.SetList(dotPath, value, opts...)
+}
+
+func (s *SyncJSONStruct) Delete(dotPath string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.s.Delete(dotPath)
+}
@@ -0,0 +1,115 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SyncJSONStruct", func() {
+	var (
+		values *jsonstruct.SyncJSONStruct
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.NewSync(nil)
+	})
+
+	It("wraps an existing document", func() {
+		doc := jsonstruct.New()
+		Expect(doc.SetString(".parent.child", "value")).To(Succeed())
+
+		values = jsonstruct.NewSync(doc)
+		Expect(values.StringWithDefault(".parent.child", "")).To(Equal("value"))
+	})
+
+	It("gets and sets values", func() {
+		Expect(values.SetString(".string", "value")).To(Succeed())
+		Expect(values.SetInt(".int", 42)).To(Succeed())
+		Expect(values.SetDuration(".duration", 3*time.Second)).To(Succeed())
+		Expect(values.SetList(".list", []interface{}{"a"})).To(Succeed())
+
+		Expect(values.StringWithDefault(".string", "")).To(Equal("value"))
+		Expect(values.IntWithDefault(".int", 0)).To(Equal(42))
+		Expect(values.DurationWithDefault(".duration", 0)).To(Equal(3 * time.Second))
+		list, ok := values.List(".list")
+		Expect(ok).To(BeTrue())
+		Expect(list).To(Equal([]interface{}{"a"}))
+	})
+
+	It("can be used from multiple goroutines", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(values.SetInt(fmt.Sprintf(".values.v%d", i), i)).To(Succeed())
+			}(i)
+			go func() {
+				defer wg.Done()
+				values.String(".values.v0")
+				values.Snapshot()
+			}()
+		}
+		wg.Wait()
+
+		for i := 0; i < 10; i++ {
+			Expect(values.IntWithDefault(fmt.Sprintf(".values.v%d", i), -1)).To(Equal(i))
+		}
+	})
+
+	Describe("Update()", func() {
+		It("applies all edits when the function succeeds", func() {
+			Expect(values.Update(func(s jsonstruct.JSONStruct) error {
+				Expect(s.SetString(".a", "1")).To(Succeed())
+				return s.SetString(".b", "2")
+			})).To(Succeed())
+
+			Expect(values.StringWithDefault(".a", "")).To(Equal("1"))
+			Expect(values.StringWithDefault(".b", "")).To(Equal("2"))
+		})
+
+		It("discards all edits when the function fails", func() {
+			Expect(values.SetString(".a", "original")).To(Succeed())
+
+			failure := errors.New("failure")
+			err := values.Update(func(s jsonstruct.JSONStruct) error {
+				Expect(s.SetString(".a", "changed")).To(Succeed())
+				Expect(s.SetString(".b", "added")).To(Succeed())
+				return failure
+			})
+			Expect(err).To(Equal(failure))
+
+			Expect(values.StringWithDefault(".a", "")).To(Equal("original"))
+			_, ok := values.String(".b")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("Snapshot()", func() {
+		It("returns a copy that is independent of the wrapped document", func() {
+			Expect(values.SetString(".parent.child", "before")).To(Succeed())
+
+			snapshot := values.Snapshot()
+			Expect(values.SetString(".parent.child", "after")).To(Succeed())
+
+			Expect(snapshot.StringWithDefault(".parent.child", "")).To(Equal("before"))
+		})
+	})
+
+	It("marshals the wrapped document", func() {
+		Expect(values.SetString(".parent.child", "value")).To(Succeed())
+
+		data, err := json.Marshal(values)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"parent": {"child": "value"}}`))
+	})
+})
@@ -0,0 +1,108 @@
+package jsonstruct
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+func ParseTOML(data []byte) (JSONStruct, error) {
+	var raw map[string]interface{}
+	err := toml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return JSONStruct(fromTOML(raw).(map[string]interface{})), nil
+}
+
+// MarshalTOML returns an error for null values, which TOML cannot represent
+// and the encoder would otherwise silently drop.
+func MarshalTOML(s JSONStruct) ([]byte, error) {
+	err := checkTOMLNulls(".", map[string]interface{}(s))
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	err = toml.NewEncoder(&buffer).Encode(map[string]interface{}(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func checkTOMLNulls(path string, value interface{}) error {
+	switch value := asObject(value).(type) {
+	case nil:
+		return &PathError{Path: path, Err: errors.New("Null values cannot be represented in TOML")}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			err := checkTOMLNulls(childPath(path, key), value[key])
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, element := range value {
+			err := checkTOMLNulls(path+"["+strconv.Itoa(i)+"]", element)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fromTOML(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		msi := make(map[string]interface{}, len(value))
+		for key, child := range value {
+			msi[key] = fromTOML(child)
+		}
+		return msi
+	case []map[string]interface{}:
+		// Arrays of tables
+		list := make([]interface{}, len(value))
+		for i, child := range value {
+			list[i] = fromTOML(child)
+		}
+		return list
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, child := range value {
+			list[i] = fromTOML(child)
+		}
+		return list
+	case int64:
+		if value < math.MinInt || value > math.MaxInt {
+			return float64(value)
+		}
+		return int(value)
+	case time.Time:
+		return formatTOMLTime(value)
+	default:
+		return value
+	}
+}
+
+func formatTOMLTime(value time.Time) string {
+	// Local dates and times have no offset so they are kept in their
+	// original form rather than being pinned to the machine's time zone.
+	switch value.Location().String() {
+	case "datetime-local":
+		return value.Format("2006-01-02T15:04:05.999999999")
+	case "date-local":
+		return value.Format("2006-01-02")
+	case "time-local":
+		return value.Format("15:04:05.999999999")
+	default:
+		return value.Format(time.RFC3339Nano)
+	}
+}
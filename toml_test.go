@@ -0,0 +1,130 @@
+package jsonstruct_test
+
+import (
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TOML", func() {
+	Describe("ParseTOML()", func() {
+		It("maps tables to nested objects", func() {
+			values, err := jsonstruct.ParseTOML([]byte(`
+firstName = "John"
+age = 25
+
+[address]
+city = "New York"
+
+[address.geo]
+lat = 40.7
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			firstName, ok := values.String(".firstName")
+			Expect(ok).To(BeTrue())
+			Expect(firstName).To(Equal("John"))
+
+			age, ok := values.Int(".age")
+			Expect(ok).To(BeTrue())
+			Expect(age).To(Equal(25))
+
+			city, ok := values.String(".address.city")
+			Expect(ok).To(BeTrue())
+			Expect(city).To(Equal("New York"))
+
+			lat, ok := values.String(".address.geo.lat")
+			Expect(ok).To(BeTrue())
+			Expect(lat).To(Equal("40.7"))
+		})
+
+		It("maps arrays of tables to lists of objects", func() {
+			values, err := jsonstruct.ParseTOML([]byte(`
+[[phoneNumbers]]
+type = "home"
+number = "212 555-1234"
+
+[[phoneNumbers]]
+type = "office"
+number = "646 555-4567"
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			list, ok := values.List(".phoneNumbers")
+			Expect(ok).To(BeTrue())
+			Expect(list).To(Equal([]interface{}{
+				map[string]interface{}{"type": "home", "number": "212 555-1234"},
+				map[string]interface{}{"type": "office", "number": "646 555-4567"},
+			}))
+		})
+
+		It("converts plain arrays", func() {
+			values, err := jsonstruct.ParseTOML([]byte(`children = ["Catherine", "Thomas"]`))
+			Expect(err).NotTo(HaveOccurred())
+
+			children, ok := values.List(".children")
+			Expect(ok).To(BeTrue())
+			Expect(children).To(Equal([]interface{}{"Catherine", "Thomas"}))
+		})
+
+		It("maps datetimes to RFC 3339 strings", func() {
+			values, err := jsonstruct.ParseTOML([]byte(`
+offset = 1979-05-27T07:32:00-08:00
+local = 1979-05-27T07:32:00
+date = 1979-05-27
+time = 07:32:00.5
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			offset, ok := values.String(".offset")
+			Expect(ok).To(BeTrue())
+			Expect(offset).To(Equal("1979-05-27T07:32:00-08:00"))
+			parsed, err := time.Parse(time.RFC3339, offset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Unix()).To(Equal(int64(296667120)))
+
+			Expect(values.StringWithDefault(".local", "")).To(Equal("1979-05-27T07:32:00"))
+			Expect(values.StringWithDefault(".date", "")).To(Equal("1979-05-27"))
+			Expect(values.StringWithDefault(".time", "")).To(Equal("07:32:00.5"))
+		})
+
+		It("returns an error for invalid TOML", func() {
+			_, err := jsonstruct.ParseTOML([]byte("not = = toml"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("MarshalTOML()", func() {
+		It("round trips a document", func() {
+			values := jsonstruct.New()
+			Expect(values.SetString(".parent.child", "value")).To(Succeed())
+			Expect(values.SetInt(".count", 3)).To(Succeed())
+			Expect(values.SetList(".list", []interface{}{"a", "b"})).To(Succeed())
+			Expect(values.SetList(".tables", []interface{}{
+				map[string]interface{}{"name": "one"},
+				map[string]interface{}{"name": "two"},
+			})).To(Succeed())
+
+			data, err := jsonstruct.MarshalTOML(values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("[[tables]]"))
+
+			parsed, err := jsonstruct.ParseTOML(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(values))
+		})
+
+		It("returns an error for null values", func() {
+			_, err := jsonstruct.MarshalTOML(jsonstruct.JSONStruct{"a": nil, "b": 1})
+			Expect(err).To(MatchError(".a: Null values cannot be represented in TOML"))
+
+			_, err = jsonstruct.MarshalTOML(jsonstruct.JSONStruct{
+				"parent": map[string]interface{}{"list": []interface{}{1, nil}},
+			})
+			Expect(err).To(MatchError(".parent.list[1]: Null values cannot be represented in TOML"))
+		})
+	})
+})
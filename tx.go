@@ -0,0 +1,138 @@
+package jsonstruct
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrTxDone = errors.New("Transaction has already been committed or rolled back")
+
+// Tx records how to undo each change made through it so Rollback can restore
+// the document without copying it up front. Changes are applied to the
+// document immediately and are visible to its getters before Commit. The
+// document must not be modified other than through the Tx until it is
+// committed or rolled back.
+type Tx struct {
+	s    JSONStruct
+	undo []undoEntry
+	done bool
+}
+
+type undoEntry struct {
+	keys     []string
+	oldValue interface{}
+	existed  bool
+}
+
+func (s JSONStruct) Begin() *Tx {
+	return &Tx{s: s}
+}
+
+func (t *Tx) SetString(dotPath, value string, opts ...SetOption) error {
+	return t.set(dotPath, value, opts)
+}
+
+func (t *Tx) SetInt(dotPath string, value int, opts ...SetOption) error {
+	return t.set(dotPath, value, opts)
+}
+
+func (t *Tx) SetDuration(dotPath string, value time.Duration, opts ...SetOption) error {
+	return t.set(dotPath, value.String(), opts)
+}
+
+func (t *Tx) SetList(dotPath string, value []interface{}, opts ...SetOption) error {
+	return t.set(dotPath, value, opts)
+}
+
+func (t *Tx) Delete(dotPath string) (bool, error) {
+	if t.done {
+		return false, ErrTxDone
+	}
+
+	keys, err := splitPath(dotPath)
+	if err != nil {
+		return false, err
+	}
+
+	oldValue, existed := t.s.findElement(keys)
+	if !existed {
+		return false, nil
+	}
+
+	t.undo = append(t.undo, undoEntry{keys: keys, oldValue: oldValue, existed: true})
+	return t.s.deleteKeys(keys), nil
+}
+
+func (t *Tx) Commit() error {
+	if t.done {
+		return ErrTxDone
+	}
+
+	t.done = true
+	t.undo = nil
+	return nil
+}
+
+func (t *Tx) Rollback() error {
+	if t.done {
+		return ErrTxDone
+	}
+
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		entry := t.undo[i]
+		if entry.existed {
+			parent, lastKey, _ := t.s.findParentKeys(entry.keys, []SetOption{Force})
+			parent[lastKey] = entry.oldValue
+		} else {
+			t.s.deleteKeys(entry.keys)
+		}
+	}
+
+	t.done = true
+	t.undo = nil
+	return nil
+}
+
+func (t *Tx) set(dotPath string, value interface{}, opts []SetOption) error {
+	if t.done {
+		return ErrTxDone
+	}
+
+	keys, err := splitPath(dotPath)
+	if err != nil {
+		return err
+	}
+
+	entry := t.s.undoEntry(keys)
+	parent, lastKey, err := t.s.findParentKeys(keys, opts)
+	if err != nil {
+		return err
+	}
+	parent[lastKey] = value
+
+	t.undo = append(t.undo, entry)
+	return nil
+}
+
+// undoEntry records the shallowest value the setter will replace: either the
+// value at keys, or the first parent that is missing or is not an object and
+// so will be created or overwritten.
+func (s JSONStruct) undoEntry(keys []string) undoEntry {
+	parent := map[string]interface{}(s)
+	for i, key := range keys {
+		value, ok := parent[key]
+		if !ok {
+			return undoEntry{keys: keys[:i+1]}
+		}
+		if i+1 == len(keys) {
+			return undoEntry{keys: keys, oldValue: value, existed: true}
+		}
+
+		parent, ok = asObject(value).(map[string]interface{})
+		if !ok {
+			return undoEntry{keys: keys[:i+1], oldValue: value, existed: true}
+		}
+	}
+
+	return undoEntry{keys: keys}
+}
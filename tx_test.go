@@ -0,0 +1,97 @@
+package jsonstruct_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tx", func() {
+	var (
+		values   jsonstruct.JSONStruct
+		original jsonstruct.JSONStruct
+		tx       *jsonstruct.Tx
+	)
+
+	BeforeEach(func() {
+		values = jsonstruct.New()
+		Expect(values.SetString(".server.host", "localhost")).To(Succeed())
+		Expect(values.SetInt(".server.port", 8080)).To(Succeed())
+		Expect(values.SetString(".name", "service")).To(Succeed())
+		Expect(values.SetList(".tags", []interface{}{"a", "b"})).To(Succeed())
+		original = values.DeepCopy()
+
+		tx = values.Begin()
+	})
+
+	It("applies changes immediately and keeps them on commit", func() {
+		Expect(tx.SetInt(".server.port", 9090)).To(Succeed())
+		Expect(values.IntWithDefault(".server.port", 0)).To(Equal(9090))
+
+		Expect(tx.Commit()).To(Succeed())
+		Expect(values.IntWithDefault(".server.port", 0)).To(Equal(9090))
+	})
+
+	It("restores the document on rollback", func() {
+		Expect(tx.SetString(".server.host", "example.com")).To(Succeed())
+		Expect(tx.SetInt(".server.port", 9090)).To(Succeed())
+		Expect(tx.SetDuration(".server.timeout", 3*time.Second)).To(Succeed())
+		Expect(tx.SetList(".tags", []interface{}{"c"})).To(Succeed())
+		Expect(tx.SetString(".admin.tls.cert", "cert.pem")).To(Succeed())
+		deleted, err := tx.Delete(".name")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeTrue())
+
+		Expect(tx.Rollback()).To(Succeed())
+		Expect(values).To(Equal(original))
+	})
+
+	It("restores values replaced by forced sets", func() {
+		Expect(tx.SetString(".name.first", "service", jsonstruct.Force)).To(Succeed())
+		Expect(tx.SetString(".server", "replaced")).To(Succeed())
+
+		Expect(tx.Rollback()).To(Succeed())
+		Expect(values).To(Equal(original))
+	})
+
+	It("restores values changed more than once", func() {
+		Expect(tx.SetInt(".server.port", 9090)).To(Succeed())
+		deleted, err := tx.Delete(".server")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeTrue())
+		Expect(tx.SetInt(".server.port", 9091)).To(Succeed())
+
+		Expect(tx.Rollback()).To(Succeed())
+		Expect(values).To(Equal(original))
+	})
+
+	It("does not record failed sets", func() {
+		err := tx.SetString(".name.first", "service")
+		Expect(errors.Is(err, jsonstruct.ErrNotAnObject)).To(BeTrue())
+		Expect(tx.SetString("name", "service")).To(MatchError(jsonstruct.ErrUnsupportedPath))
+
+		Expect(tx.Rollback()).To(Succeed())
+		Expect(values).To(Equal(original))
+	})
+
+	It("reports missing values on delete", func() {
+		deleted, err := tx.Delete(".missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("cannot be used after it is finished", func() {
+		Expect(tx.Commit()).To(Succeed())
+
+		Expect(tx.SetString(".name", "other")).To(MatchError(jsonstruct.ErrTxDone))
+		_, err := tx.Delete(".name")
+		Expect(err).To(MatchError(jsonstruct.ErrTxDone))
+		Expect(tx.Commit()).To(MatchError(jsonstruct.ErrTxDone))
+		Expect(tx.Rollback()).To(MatchError(jsonstruct.ErrTxDone))
+		Expect(values).To(Equal(original))
+	})
+})
@@ -0,0 +1,399 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks the document against a JSON Schema (draft 2020-12). Only
+// the type, required, properties, additionalProperties, items, enum,
+// minimum, maximum, pattern, format and local $ref keywords are supported;
+// any other keywords are ignored.
+func (s JSONStruct) Validate(schema JSONStruct) []ValidationError {
+	v := &validator{
+		root:     map[string]interface{}(schema),
+		patterns: make(map[string]*regexp.Regexp),
+		active:   make(map[string]bool),
+	}
+	v.validate(".", map[string]interface{}(s), map[string]interface{}(schema))
+	return v.errs
+}
+
+type validator struct {
+	root     map[string]interface{}
+	patterns map[string]*regexp.Regexp
+	active   map[string]bool
+	errs     []ValidationError
+}
+
+func (v *validator) fail(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(path string, value, schema interface{}) {
+	value = canonicalValue(path, value)
+
+	switch schema := asObject(schema).(type) {
+	case bool:
+		if !schema {
+			v.fail(path, "No values are allowed")
+		}
+	case map[string]interface{}:
+		v.validateObject(path, value, schema)
+	default:
+		v.fail(path, "Invalid schema of type %T", schema)
+	}
+}
+
+// canonicalValue converts Go values set directly into the document, such as a
+// []string or map[string]string, to the types the keywords check
+func canonicalValue(path string, value interface{}) interface{} {
+	switch value.(type) {
+	case nil, bool, string, float64, int, int64, json.Number, map[string]interface{}, JSONStruct, []interface{}:
+		return value
+	}
+
+	normalized, err := normalize(path, reflect.ValueOf(value))
+	if err != nil {
+		return value
+	}
+	return normalized
+}
+
+func (v *validator) validateObject(path string, value interface{}, schema map[string]interface{}) {
+	if ref, ok := schema["$ref"].(string); ok {
+		v.validateRef(path, value, ref)
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(value, types) {
+		v.fail(path, "Expected type %s but found %s", formatTypes(types), typeName(value))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if jsonEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "Value %s is not one of the allowed values", formatValue(value))
+		}
+	}
+
+	if number, ok := toFloat64(value); ok {
+		if minimum, ok := toFloat64(schema["minimum"]); ok && number < minimum {
+			v.fail(path, "Value %s is less than the minimum of %s", formatValue(value), formatValue(schema["minimum"]))
+		}
+		if maximum, ok := toFloat64(schema["maximum"]); ok && number > maximum {
+			v.fail(path, "Value %s is greater than the maximum of %s", formatValue(value), formatValue(schema["maximum"]))
+		}
+	}
+
+	if str, ok := value.(string); ok {
+		if pattern, ok := schema["pattern"].(string); ok {
+			v.validatePattern(path, str, pattern)
+		}
+		if format, ok := schema["format"].(string); ok && !matchesFormat(str, format) {
+			v.fail(path, "Value %q is not a valid %s", str, format)
+		}
+	}
+
+	if object, ok := asObject(value).(map[string]interface{}); ok {
+		v.validateProperties(path, object, schema)
+	}
+
+	if list, ok := value.([]interface{}); ok {
+		if items, ok := schema["items"]; ok {
+			for i, item := range list {
+				v.validate(path+"["+strconv.Itoa(i)+"]", item, items)
+			}
+		}
+	}
+}
+
+func (v *validator) validateRef(path string, value interface{}, ref string) {
+	target, ok := resolvePointer(v.root, ref)
+	if !ok {
+		v.fail(path, "Unable to resolve $ref %q", ref)
+		return
+	}
+
+	// A $ref that loops back on itself without descending into the value
+	// would otherwise recurse forever
+	key := ref + " " + path
+	if v.active[key] {
+		v.fail(path, "Circular $ref %q", ref)
+		return
+	}
+	v.active[key] = true
+	v.validate(path, value, target)
+	delete(v.active, key)
+}
+
+func (v *validator) validatePattern(path, value, pattern string) {
+	re, ok := v.patterns[pattern]
+	if !ok {
+		var err error
+		re, err = regexp.Compile(pattern)
+		if err != nil {
+			v.fail(path, "Invalid pattern %q: %s", pattern, err)
+			return
+		}
+		v.patterns[pattern] = re
+	}
+
+	if !re.MatchString(value) {
+		v.fail(path, "Value %q does not match pattern %q", value, pattern)
+	}
+}
+
+func (v *validator) validateProperties(path string, object, schema map[string]interface{}) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			name, ok := name.(string)
+			if !ok {
+				continue
+			}
+			if _, ok := object[name]; !ok {
+				v.fail(childPath(path, name), "Required value is missing")
+			}
+		}
+	}
+
+	properties, _ := asObject(schema["properties"]).(map[string]interface{})
+	additional, hasAdditional := schema["additionalProperties"]
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if property, ok := properties[key]; ok {
+			v.validate(childPath(path, key), object[key], property)
+		} else if hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				v.fail(childPath(path, key), "Additional property is not allowed")
+			} else {
+				v.validate(childPath(path, key), object[key], additional)
+			}
+		}
+	}
+}
+
+func resolvePointer(root map[string]interface{}, ref string) (interface{}, bool) {
+	if ref == "#" {
+		return root, true
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+
+	var current interface{} = root
+	for _, token := range strings.Split(ref[2:], "/") {
+		// URI fragments are percent-decoded before the RFC 6901 escapes
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.Replace(token, "~1", "/", -1)
+		token = strings.Replace(token, "~0", "~", -1)
+
+		switch node := asObject(current).(type) {
+		case map[string]interface{}:
+			var ok bool
+			current, ok = node[token]
+			if !ok {
+				return nil, false
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func matchesType(value, types interface{}) bool {
+	switch types := types.(type) {
+	case string:
+		return matchesSingleType(value, types)
+	case []interface{}:
+		for _, t := range types {
+			if t, ok := t.(string); ok && matchesSingleType(value, t) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func matchesSingleType(value interface{}, t string) bool {
+	switch t {
+	case "integer":
+		number, ok := toFloat64(value)
+		return ok && number == math.Trunc(number)
+	default:
+		return typeName(value) == t
+	}
+}
+
+func formatTypes(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, len(list))
+		for i, t := range list {
+			names[i] = fmt.Sprint(t)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+func typeName(value interface{}) string {
+	kind := kindOf(value)
+	if kind == Missing {
+		return fmt.Sprintf("%T", value)
+	}
+	return kind.String()
+}
+
+func matchesFormat(value, format string) bool {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+	case "time":
+		_, err = time.Parse("15:04:05Z07:00", value)
+	case "duration":
+		_, err = ParseDuration(value)
+	case "email":
+		var address *mail.Address
+		address, err = mail.ParseAddress(value)
+		if err == nil && address.Address != value {
+			return false
+		}
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+	case "ipv6":
+		ip := net.ParseIP(value)
+		return ip != nil && strings.Contains(value, ":")
+	case "uri":
+		var u *url.URL
+		u, err = url.Parse(value)
+		if err == nil && u.Scheme == "" {
+			return false
+		}
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	}
+	// Unknown formats are annotations only
+	return err == nil
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func jsonEqual(a, b interface{}) bool {
+	if x, ok := toFloat64(a); ok {
+		y, ok := toFloat64(b)
+		return ok && x == y
+	}
+
+	switch a := asObject(a).(type) {
+	case map[string]interface{}:
+		b, ok := asObject(b).(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == asObject(b)
+	}
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func childPath(path, key string) string {
+	if path == "." {
+		return "." + key
+	}
+	return path + "." + key
+}
+
+func asObject(value interface{}) interface{} {
+	if s, ok := value.(JSONStruct); ok {
+		return map[string]interface{}(s)
+	}
+	return value
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,193 @@
+package jsonstruct_test
+
+import (
+	"encoding/json"
+
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	parse := func(data string) jsonstruct.JSONStruct {
+		var values jsonstruct.JSONStruct
+		Expect(json.Unmarshal([]byte(data), &values)).To(Succeed())
+		return values
+	}
+
+	paths := func(errs []jsonstruct.ValidationError) []string {
+		var result []string
+		for _, err := range errs {
+			result = append(result, err.Path)
+		}
+		return result
+	}
+
+	var schema jsonstruct.JSONStruct
+
+	BeforeEach(func() {
+		schema = parse(`{
+			"type": "object",
+			"required": ["server"],
+			"properties": {
+				"server": {
+					"type": "object",
+					"required": ["host", "port"],
+					"properties": {
+						"host": { "type": "string", "format": "hostname" },
+						"port": { "type": "integer", "minimum": 1, "maximum": 65535 },
+						"timeout": { "type": "string", "format": "duration" }
+					},
+					"additionalProperties": false
+				},
+				"log": { "$ref": "#/$defs/log" },
+				"children": { "type": "array", "items": { "type": "string" } }
+			},
+			"$defs": {
+				"log": {
+					"type": "object",
+					"properties": {
+						"level": { "enum": ["debug", "info", "warn", "error"] },
+						"file": { "type": ["string", "null"], "pattern": "\\.log$" }
+					}
+				}
+			}
+		}`)
+	})
+
+	It("returns no errors for a valid document", func() {
+		values := parse(`{
+			"server": { "host": "localhost", "port": 8080, "timeout": "5s" },
+			"log": { "level": "info", "file": null },
+			"children": ["a", "b"]
+		}`)
+
+		Expect(values.Validate(schema)).To(BeEmpty())
+	})
+
+	It("validates values set with setters", func() {
+		values := jsonstruct.New()
+		Expect(values.SetString(".server.host", "localhost")).To(Succeed())
+		Expect(values.SetInt(".server.port", 80)).To(Succeed())
+
+		Expect(values.Validate(schema)).To(BeEmpty())
+	})
+
+	It("reports missing required values", func() {
+		Expect(paths(parse(`{}`).Validate(schema))).To(Equal([]string{".server"}))
+		Expect(paths(parse(`{"server": {}}`).Validate(schema))).To(Equal([]string{".server.host", ".server.port"}))
+	})
+
+	It("reports every violation as a dot path", func() {
+		values := parse(`{
+			"server": { "host": "localhost", "port": 70000, "extra": true },
+			"log": { "level": "trace", "file": "out.txt" },
+			"children": ["a", 2]
+		}`)
+
+		errs := values.Validate(schema)
+		Expect(paths(errs)).To(Equal([]string{
+			".children[1]",
+			".log.file",
+			".log.level",
+			".server.extra",
+			".server.port",
+		}))
+		Expect(errs[4].Error()).To(Equal(".server.port: Value 70000 is greater than the maximum of 65535"))
+	})
+
+	It("reports an unresolvable $ref", func() {
+		schema = parse(`{ "$ref": "#/$defs/missing" }`)
+		errs := parse(`{}`).Validate(schema)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Message).To(ContainSubstring("#/$defs/missing"))
+	})
+
+	It("does not loop forever on a self-referencing $ref", func() {
+		schema = parse(`{ "$ref": "#" }`)
+		Expect(parse(`{}`).Validate(schema)).To(HaveLen(1))
+	})
+
+	It("percent-decodes $ref pointers before unescaping", func() {
+		schema = parse(`{
+			"$defs": {
+				"a/b": { "type": "string" },
+				"a~1b": { "type": "integer" },
+				"c d": { "type": "string" }
+			},
+			"properties": {
+				"escaped": { "$ref": "#/$defs/a~1b" },
+				"encoded": { "$ref": "#/$defs/a%7E1b" },
+				"space": { "$ref": "#/$defs/c%20d" }
+			}
+		}`)
+
+		values := parse(`{ "escaped": "x", "encoded": "x", "space": "x" }`)
+		Expect(values.Validate(schema)).To(BeEmpty())
+	})
+
+	It("validates Go slices and maps set directly", func() {
+		schema = parse(`{
+			"properties": {
+				"tags": { "type": "array", "items": { "type": "string", "pattern": "^[a-z]+$" } },
+				"labels": { "type": "object", "additionalProperties": { "enum": ["x"] } },
+				"ports": { "type": "array", "items": { "maximum": 10 } }
+			}
+		}`)
+
+		values := jsonstruct.JSONStruct{
+			"tags":   []string{"ok", "NOT"},
+			"labels": map[string]string{"a": "x", "b": "y"},
+			"ports":  []int32{1, 20},
+		}
+		Expect(paths(values.Validate(schema))).To(Equal([]string{".labels.b", ".ports[1]", ".tags[1]"}))
+	})
+
+	It("supports recursive schemas", func() {
+		schema = parse(`{
+			"$defs": {
+				"node": {
+					"type": "object",
+					"properties": {
+						"name": { "type": "string" },
+						"child": { "$ref": "#/$defs/node" }
+					}
+				}
+			},
+			"$ref": "#/$defs/node"
+		}`)
+
+		values := parse(`{ "name": "a", "child": { "name": "b", "child": { "name": 3 } } }`)
+		Expect(paths(values.Validate(schema))).To(Equal([]string{".child.child.name"}))
+	})
+
+	It("applies additionalProperties schemas", func() {
+		schema = parse(`{ "additionalProperties": { "type": "integer" } }`)
+		Expect(paths(parse(`{"a": 1, "b": 1.5}`).Validate(schema))).To(Equal([]string{".b"}))
+	})
+
+	DescribeTable("formats", func(format, value string, valid bool) {
+		schema = parse(`{ "properties": { "value": { "format": "` + format + `" } } }`)
+		values := jsonstruct.New()
+		Expect(values.SetString(".value", value)).To(Succeed())
+		Expect(values.Validate(schema)).To(HaveLen(map[bool]int{true: 0, false: 1}[valid]))
+	},
+		Entry("valid date-time", "date-time", "2020-01-02T03:04:05Z", true),
+		Entry("invalid date-time", "date-time", "2020-01-02", false),
+		Entry("valid date", "date", "2020-01-02", true),
+		Entry("invalid date", "date", "2020-13-02", false),
+		Entry("valid email", "email", "john@example.com", true),
+		Entry("invalid email", "email", "john", false),
+		Entry("valid ipv4", "ipv4", "10.0.0.1", true),
+		Entry("invalid ipv4", "ipv4", "::1", false),
+		Entry("valid ipv6", "ipv6", "::1", true),
+		Entry("invalid ipv6", "ipv6", "10.0.0.1", false),
+		Entry("valid uri", "uri", "https://example.com/x", true),
+		Entry("invalid uri", "uri", "example", false),
+		Entry("valid uuid", "uuid", "123e4567-e89b-12d3-a456-426614174000", true),
+		Entry("invalid uuid", "uuid", "123e4567", false),
+		Entry("unknown format", "made-up", "anything", true),
+	)
+})
@@ -0,0 +1,80 @@
+package jsonstruct
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+)
+
+func ParseYAML(data []byte) (JSONStruct, error) {
+	var raw map[interface{}]interface{}
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := fromYAML("", raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return JSONStruct(value.(map[string]interface{})), nil
+}
+
+func MarshalYAML(s JSONStruct) ([]byte, error) {
+	return yaml.Marshal(map[string]interface{}(s))
+}
+
+func fromYAML(path string, value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		msi := make(map[string]interface{}, len(value))
+		for key, child := range value {
+			stringKey, ok := key.(string)
+			if !ok {
+				return nil, &PathError{
+					Path: pathOrRoot(path),
+					Err:  fmt.Errorf("Non-string key %v (%T) is not supported", key, key),
+				}
+			}
+
+			converted, err := fromYAML(path+"."+stringKey, child)
+			if err != nil {
+				return nil, err
+			}
+			msi[stringKey] = converted
+		}
+		return msi, nil
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, child := range value {
+			converted, err := fromYAML(path+"["+strconv.Itoa(i)+"]", child)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = converted
+		}
+		return list, nil
+	case int64:
+		if value < math.MinInt || value > math.MaxInt {
+			return float64(value), nil
+		}
+		return int(value), nil
+	case uint64:
+		if value > math.MaxInt {
+			return float64(value), nil
+		}
+		return int(value), nil
+	default:
+		return value, nil
+	}
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
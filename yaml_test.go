@@ -0,0 +1,103 @@
+package jsonstruct_test
+
+import (
+	"github.com/myshkin5/jsonstruct"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("YAML", func() {
+	Describe("ParseYAML()", func() {
+		It("parses nested values into the canonical representation", func() {
+			values, err := jsonstruct.ParseYAML([]byte(`
+firstName: John
+age: 25
+height: 1.8
+address:
+  city: New York
+children:
+  - Catherine
+  - Thomas
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			firstName, ok := values.String(".firstName")
+			Expect(ok).To(BeTrue())
+			Expect(firstName).To(Equal("John"))
+
+			age, ok := values.Int(".age")
+			Expect(ok).To(BeTrue())
+			Expect(age).To(Equal(25))
+
+			height, ok := values.String(".height")
+			Expect(ok).To(BeTrue())
+			Expect(height).To(Equal("1.8"))
+
+			city, ok := values.String(".address.city")
+			Expect(ok).To(BeTrue())
+			Expect(city).To(Equal("New York"))
+
+			children, ok := values.List(".children")
+			Expect(ok).To(BeTrue())
+			Expect(children).To(Equal([]interface{}{"Catherine", "Thomas"}))
+		})
+
+		It("converts maps nested in lists", func() {
+			values, err := jsonstruct.ParseYAML([]byte(`
+phoneNumbers:
+  - type: home
+    number: 212 555-1234
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			list, ok := values.List(".phoneNumbers")
+			Expect(ok).To(BeTrue())
+			Expect(list).To(HaveLen(1))
+			Expect(list[0]).To(Equal(map[string]interface{}{
+				"type":   "home",
+				"number": "212 555-1234",
+			}))
+		})
+
+		It("returns an empty document for empty input", func() {
+			values, err := jsonstruct.ParseYAML([]byte(""))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(BeEmpty())
+		})
+
+		It("rejects non-string keys with the path of the offending map", func() {
+			_, err := jsonstruct.ParseYAML([]byte(`
+parent:
+  list:
+    - 1: one
+`))
+			Expect(err).To(HaveOccurred())
+
+			pathErr, ok := err.(*jsonstruct.PathError)
+			Expect(ok).To(BeTrue())
+			Expect(pathErr.Path).To(Equal(".parent.list[0]"))
+		})
+
+		It("returns an error for invalid YAML", func() {
+			_, err := jsonstruct.ParseYAML([]byte("- not\n- a map"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("MarshalYAML()", func() {
+		It("round trips a document", func() {
+			values := jsonstruct.New()
+			Expect(values.SetString(".parent.child", "value")).To(Succeed())
+			Expect(values.SetInt(".count", 3)).To(Succeed())
+			Expect(values.SetList(".list", []interface{}{"a", 1})).To(Succeed())
+
+			data, err := jsonstruct.MarshalYAML(values)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed, err := jsonstruct.ParseYAML(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(values))
+		})
+	})
+})